@@ -0,0 +1,1260 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"image/color"
+	"io"
+	"os"
+	"os/signal"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fathurrohman26/favicongen/internal/generator"
+	"github.com/fathurrohman26/favicongen/processor"
+)
+
+var (
+	Version    = "dev"
+	BuildDate  = "unknown"
+	CommitHash = "unknown"
+)
+
+type Config struct {
+	Source              string
+	Output              string
+	Targets             []generator.Target
+	NameTemplate        string
+	Backend             string
+	Jobs                int
+	Upscale             bool
+	Fit                 string
+	Gravity             string
+	Padding             string
+	Background          string
+	Timeout             time.Duration
+	GenerateHTML        bool
+	GenerateManifest    bool
+	GenerateICO         bool
+	ICOSizes            []int
+	ICOEncoding         string
+	AppleTouchIcon      bool
+	AppleTouchSizes     []int
+	AppleTouchPadding   string
+	AppleTouchRoot      bool
+	Android             bool
+	AndroidSizes        []int
+	Maskable            bool
+	MaskableSizes       []int
+	Monochrome          bool
+	MonochromeSizes     []int
+	MonochromeThreshold string
+	MaskIcon            bool
+	MaskIconColor       string
+	SVGFavicon          bool
+	SVGDarkFill         string
+	SVGDarkColors       string
+	Windows             bool
+	TileColor           string
+	Splash              bool
+	GenerateHTMLOnly    bool
+	AppName             string
+	AppShortName        string
+	AppDescription      string
+	AppStartURL         string
+	AppDisplay          string
+	AppOrientation      string
+	AppScope            string
+	AppThemeColor       string
+	AppBackgroundColor  string
+	AppCategories       []string
+	AppIcon             string
+}
+
+type flags struct {
+	source              *string
+	output              *string
+	sizesStr            *string
+	nameTemplate        *string
+	preset              *string
+	backend             *string
+	jobs                *int
+	upscale             *bool
+	fit                 *string
+	gravity             *string
+	padding             *string
+	background          *string
+	timeout             *time.Duration
+	generateHTML        *bool
+	generateManifest    *bool
+	generateICO         *bool
+	icoSizesStr         *string
+	icoEncoding         *string
+	appleTouchIcon      *bool
+	appleTouchSizesStr  *string
+	appleTouchPadding   *string
+	appleTouchRoot      *bool
+	android             *bool
+	androidSizesStr     *string
+	maskable            *bool
+	maskableSizesStr    *string
+	monochrome          *bool
+	monochromeSizesStr  *string
+	monochromeThreshold *string
+	maskIcon            *bool
+	maskIconColor       *string
+	svgFavicon          *bool
+	svgDarkFill         *string
+	svgDarkColors       *string
+	windows             *bool
+	tileColor           *string
+	splash              *bool
+	generateHTMLOnly    *bool
+	appName             *string
+	appShortName        *string
+	appDescription      *string
+	appStartURL         *string
+	appDisplay          *string
+	appOrientation      *string
+	appScope            *string
+	appThemeColor       *string
+	appBackgroundColor  *string
+	appCategories       *string
+	appIcon             *string
+	showVersion         *bool
+	showHelp            *bool
+}
+
+func defineFlags(fs *flag.FlagSet) *flags {
+	return &flags{
+		source:              fs.String("source", "", "Path to source image (SVG, PNG or ICO)"),
+		output:              fs.String("output", "./favicons", "Output directory for generated files"),
+		sizesStr:            fs.String("sizes", "16,32,48,64,128,180,256,512", "Comma-separated list of sizes (N or WxH, optionally :NAME, :padding=VALUE, :background=VALUE)"),
+		nameTemplate:        fs.String("name-template", string(generator.DefaultNameTemplate), "File name template for generated images ({name}, {w}, {h}, {size}, {ext})"),
+		preset:              fs.String("preset", "", "Output preset ("+strings.Join(presetNames(), ", ")+"); explicit flags override it"),
+		backend:             fs.String("backend", "", "Image processor backend ("+strings.Join(processor.Registered(), ", ")+")"),
+		jobs:                fs.Int("jobs", 0, "Maximum number of concurrent resize operations (default: GOMAXPROCS)"),
+		upscale:             fs.Bool("upscale", true, "Allow enlarging sources smaller than an output size"),
+		fit:                 fs.String("fit", "contain", "How sources are scaled into each size (contain, cover or fill)"),
+		gravity:             fs.String("gravity", "center", "Where sources are anchored when padded or cropped (center, north, southeast, ...)"),
+		padding:             fs.String("padding", "0", "Margin around the source in pixels or percent, with per-size overrides (e.g. 0,180=12%)"),
+		background:          fs.String("background", "transparent", "Canvas color or transparent, with per-size overrides (e.g. transparent,180=#ffffff)"),
+		timeout:             fs.Duration("timeout", time.Minute, "Timeout for each image processing operation (0 disables)"),
+		generateHTML:        fs.Bool("html-tags", true, "Generate HTML link tags"),
+		generateManifest:    fs.Bool("manifest", false, "Generate manifest.webmanifest file"),
+		generateICO:         fs.Bool("ico", true, "Generate favicon.ico file"),
+		icoSizesStr:         fs.String("ico-sizes", "16,32,48", "Comma-separated list of sizes bundled into favicon.ico"),
+		icoEncoding:         fs.String("ico-encoding", "", "ICO entry encoding (png, bmp or legacy)"),
+		appleTouchIcon:      fs.Bool("apple-touch-icon", true, "Generate opaque apple-touch-icon files on the app background color"),
+		appleTouchSizesStr:  fs.String("apple-touch-sizes", "180", "Comma-separated apple-touch-icon sizes (e.g. 152,167,180)"),
+		appleTouchPadding:   fs.String("apple-touch-padding", "0", "Margin around the apple-touch-icon in pixels or percent"),
+		appleTouchRoot:      fs.Bool("apple-touch-root", true, "Write the 180px apple-touch-icon as /apple-touch-icon.png, ignoring --name-template"),
+		android:             fs.Bool("android", true, "Generate android-chrome icons for the manifest (requires --manifest)"),
+		androidSizesStr:     fs.String("android-sizes", "192,512", "Comma-separated android-chrome icon sizes"),
+		maskable:            fs.Bool("maskable", true, "Generate maskable icons for the manifest (requires --manifest)"),
+		maskableSizesStr:    fs.String("maskable-sizes", "192,512", "Comma-separated maskable icon sizes"),
+		monochrome:          fs.Bool("monochrome", false, "Generate monochrome silhouette icons for themed icons"),
+		monochromeSizesStr:  fs.String("monochrome-sizes", "192,512", "Comma-separated monochrome icon sizes"),
+		monochromeThreshold: fs.String("monochrome-threshold", "0.5", "Alpha above which silhouette pixels become opaque (0 keeps soft edges)"),
+		maskIcon:            fs.Bool("mask-icon", false, "Generate safari-pinned-tab.svg from an SVG source"),
+		maskIconColor:       fs.String("mask-icon-color", "#000000", "Safari pinned tab highlight color"),
+		svgFavicon:          fs.Bool("svg", true, "Write favicon.svg when the source is SVG"),
+		svgDarkFill:         fs.String("svg-dark-fill", "", "Default fill of favicon.svg in dark mode"),
+		svgDarkColors:       fs.String("svg-dark-colors", "", "Comma-separated FROM=TO color swaps for favicon.svg in dark mode"),
+		windows:             fs.Bool("windows", false, "Generate Windows tiles and browserconfig.xml"),
+		tileColor:           fs.String("tile-color", "", "Windows tile color (default: app theme color)"),
+		splash:              fs.Bool("splash", false, "Generate iOS splash screens on the app background color"),
+		generateHTMLOnly:    fs.Bool("generate-html-tags", false, "Only generate HTML tags from existing favicons"),
+		appName:             fs.String("app-name", "", "Application name for manifest"),
+		appShortName:        fs.String("app-short-name", "", "Short application name for manifest"),
+		appDescription:      fs.String("app-description", "", "Application description for manifest"),
+		appStartURL:         fs.String("app-start-url", "/", "Start URL for manifest"),
+		appDisplay:          fs.String("app-display", "standalone", "Display mode for manifest"),
+		appOrientation:      fs.String("app-orientation", "any", "Orientation for manifest"),
+		appScope:            fs.String("app-scope", "/", "Scope for manifest"),
+		appThemeColor:       fs.String("app-theme-color", "#ffffff", "Theme color for manifest"),
+		appBackgroundColor:  fs.String("app-background-color", "#ffffff", "Background color for manifest"),
+		appCategories:       fs.String("app-categories", "", "Comma-separated categories for manifest"),
+		appIcon:             fs.String("app-icon", "", "Icon path for manifest"),
+		showVersion:         fs.Bool("version", false, "Show version information"),
+		showHelp:            fs.Bool("help", false, "Show help information"),
+	}
+}
+
+func shouldShowVersion(f *flags) bool {
+	return *f.showVersion || (len(os.Args) > 1 && os.Args[1] == "version")
+}
+
+func shouldShowHelp(f *flags) bool {
+	return *f.showHelp || len(os.Args) == 1 || (len(os.Args) > 1 && os.Args[1] == "help")
+}
+
+func printVersion() {
+	fmt.Printf("favicongen version %s\n", Version)
+	fmt.Printf("Build date: %s\n", BuildDate)
+	fmt.Printf("Commit: %s\n", CommitHash)
+}
+
+func parsePositionalArgs(f *flags, args []string) {
+	if len(args) >= 1 && *f.source == "" {
+		*f.source = args[0]
+	}
+	if len(args) >= 2 && *f.output == "./favicons" {
+		*f.output = args[1]
+	}
+}
+
+func parseCategories(categoriesStr string) []string {
+	if categoriesStr == "" {
+		return nil
+	}
+	categories := strings.Split(categoriesStr, ",")
+	for i := range categories {
+		categories[i] = strings.TrimSpace(categories[i])
+	}
+	return categories
+}
+
+func buildConfig(f *flags, targets []generator.Target, icoSizes, appleTouchSizes, androidSizes, maskableSizes, monochromeSizes []int, categories []string) *Config {
+	return &Config{
+		Source:              *f.source,
+		Output:              *f.output,
+		Targets:             targets,
+		NameTemplate:        *f.nameTemplate,
+		Backend:             *f.backend,
+		Jobs:                *f.jobs,
+		Upscale:             *f.upscale,
+		Fit:                 *f.fit,
+		Gravity:             *f.gravity,
+		Padding:             *f.padding,
+		Background:          *f.background,
+		Timeout:             *f.timeout,
+		GenerateHTML:        *f.generateHTML,
+		GenerateManifest:    *f.generateManifest,
+		GenerateICO:         *f.generateICO,
+		ICOSizes:            icoSizes,
+		ICOEncoding:         *f.icoEncoding,
+		AppleTouchIcon:      *f.appleTouchIcon,
+		AppleTouchSizes:     appleTouchSizes,
+		AppleTouchPadding:   *f.appleTouchPadding,
+		AppleTouchRoot:      *f.appleTouchRoot,
+		Android:             *f.android,
+		AndroidSizes:        androidSizes,
+		Maskable:            *f.maskable,
+		MaskableSizes:       maskableSizes,
+		Monochrome:          *f.monochrome,
+		MonochromeSizes:     monochromeSizes,
+		MonochromeThreshold: *f.monochromeThreshold,
+		MaskIcon:            *f.maskIcon,
+		MaskIconColor:       *f.maskIconColor,
+		SVGFavicon:          *f.svgFavicon,
+		SVGDarkFill:         *f.svgDarkFill,
+		SVGDarkColors:       *f.svgDarkColors,
+		Windows:             *f.windows,
+		TileColor:           *f.tileColor,
+		Splash:              *f.splash,
+		GenerateHTMLOnly:    *f.generateHTMLOnly,
+		AppName:             *f.appName,
+		AppShortName:        *f.appShortName,
+		AppDescription:      *f.appDescription,
+		AppStartURL:         *f.appStartURL,
+		AppDisplay:          *f.appDisplay,
+		AppOrientation:      *f.appOrientation,
+		AppScope:            *f.appScope,
+		AppThemeColor:       *f.appThemeColor,
+		AppBackgroundColor:  *f.appBackgroundColor,
+		AppCategories:       categories,
+		AppIcon:             *f.appIcon,
+	}
+}
+
+// subcommands maps subcommand names to their handlers
+var subcommands = map[string]func(args []string, w io.Writer) error{
+	"inspect": runInspect,
+	"extract": runExtract,
+}
+
+// Main runs the favicongen command line with os.Args and exits on failure.
+// Wrapper commands can blank-import additional processor backends before
+// calling Main so they are registered alongside the built-in ones.
+func Main() {
+	if len(os.Args) > 1 {
+		if cmd, ok := subcommands[os.Args[1]]; ok {
+			if err := cmd(os.Args[2:], os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	f := defineFlags(flag.CommandLine)
+	flag.Parse()
+
+	if shouldShowVersion(f) {
+		printVersion()
+		return
+	}
+
+	if shouldShowHelp(f) {
+		showUsage()
+		return
+	}
+
+	config, err := parseConfig(flag.CommandLine, f)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Cancel in-flight image processing on Ctrl-C or termination
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, config); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// parseConfig turns the parsed command line flags into a Config, applying
+// positional arguments and the preset first
+func parseConfig(fs *flag.FlagSet, f *flags) (*Config, error) {
+	parsePositionalArgs(f, fs.Args())
+
+	if err := applyPreset(fs, *f.preset); err != nil {
+		return nil, err
+	}
+
+	// HTML-only mode describes favicons that already exist, so it only links
+	// generated apple-touch-icons when asked to and otherwise falls back to
+	// a target of at least 180px
+	if *f.generateHTMLOnly {
+		explicit := false
+		fs.Visit(func(fl *flag.Flag) {
+			explicit = explicit || fl.Name == "apple-touch-icon" || fl.Name == "apple-touch-sizes"
+		})
+		*f.appleTouchIcon = explicit && *f.appleTouchIcon
+	}
+
+	targets, err := parseTargets(*f.sizesStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sizes format: %w", err)
+	}
+
+	icoSizes, err := parseICOSizes(*f.icoSizesStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ICO sizes format: %w", err)
+	}
+
+	appleTouchSizes, err := parseSizes(*f.appleTouchSizesStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid apple-touch-icon sizes format: %w", err)
+	}
+
+	androidSizes, err := parseSizes(*f.androidSizesStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid android-chrome sizes format: %w", err)
+	}
+
+	maskableSizes, err := parseSizes(*f.maskableSizesStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid maskable sizes format: %w", err)
+	}
+
+	monochromeSizes, err := parseSizes(*f.monochromeSizesStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid monochrome sizes format: %w", err)
+	}
+
+	categories := parseCategories(*f.appCategories)
+	return buildConfig(f, targets, icoSizes, appleTouchSizes, androidSizes, maskableSizes, monochromeSizes, categories), nil
+}
+
+func (c *Config) buildManifestConfig() *generator.ManifestConfig {
+	return &generator.ManifestConfig{
+		Name:            c.AppName,
+		ShortName:       c.AppShortName,
+		Description:     c.AppDescription,
+		StartURL:        c.AppStartURL,
+		Display:         c.AppDisplay,
+		Orientation:     c.AppOrientation,
+		Scope:           c.AppScope,
+		ThemeColor:      c.AppThemeColor,
+		BackgroundColor: c.AppBackgroundColor,
+		Categories:      c.AppCategories,
+		IconPath:        c.AppIcon,
+		Targets:         c.Targets,
+		Names:           c.names(),
+		AndroidSizes:    c.androidSizes(),
+		MaskableSizes:   c.maskableSizes(),
+		MonochromeSizes: c.monochromeSizes(),
+	}
+}
+
+func (c *Config) buildHTMLTagsConfig() *generator.HTMLTagsConfig {
+	return &generator.HTMLTagsConfig{
+		Targets:         c.Targets,
+		IncludeManifest: c.GenerateManifest,
+		ThemeColor:      c.AppThemeColor,
+		Names:           c.names(),
+		AppleTouchSizes: c.appleTouchSizes(),
+		AppleTouchRoot:  c.AppleTouchRoot,
+		IncludeICO:      c.GenerateICO && len(c.ICOSizes) > 0,
+		MaskIconColor:   c.maskIconColor(),
+		IncludeSVG:      c.svgFavicon(),
+		TileColor:       c.tileColor(),
+		SplashScreens:   c.Splash,
+	}
+}
+
+// names returns the naming template shared by the generators, the HTML tags
+// and the manifest. run rejects invalid templates before anything is
+// written, so an invalid one only falls back to the default here.
+func (c *Config) names() generator.NameTemplate {
+	names, err := generator.ParseNameTemplate(c.NameTemplate)
+	if err != nil {
+		return generator.DefaultNameTemplate
+	}
+	return names
+}
+
+// appleTouchSizes returns the apple-touch-icon sizes to generate, or nil when
+// the feature is disabled
+func (c *Config) appleTouchSizes() []int {
+	if !c.AppleTouchIcon {
+		return nil
+	}
+	return c.AppleTouchSizes
+}
+
+// androidSizes returns the android-chrome icon sizes to generate; the icons
+// are only referenced from the manifest
+func (c *Config) androidSizes() []int {
+	if !c.Android || !c.GenerateManifest {
+		return nil
+	}
+	return c.AndroidSizes
+}
+
+// maskableSizes returns the maskable icon sizes to generate; maskable icons
+// are only useful with a manifest
+func (c *Config) maskableSizes() []int {
+	if !c.Maskable || !c.GenerateManifest {
+		return nil
+	}
+	return c.MaskableSizes
+}
+
+// monochromeSizes returns the monochrome icon sizes to generate, or nil when
+// the feature is disabled
+func (c *Config) monochromeSizes() []int {
+	if !c.Monochrome {
+		return nil
+	}
+	return c.MonochromeSizes
+}
+
+// maskIconColor returns the Safari pinned tab color, or an empty string when
+// no mask icon is generated
+func (c *Config) maskIconColor() string {
+	if !c.MaskIcon {
+		return ""
+	}
+	if c.MaskIconColor == "" {
+		return "#000000"
+	}
+	return c.MaskIconColor
+}
+
+// svgFavicon reports whether favicon.svg is written, which requires an SVG
+// source
+func (c *Config) svgFavicon() bool {
+	return c.SVGFavicon && processor.SourceFormat(c.Source) == "svg"
+}
+
+// tileColor returns the Windows tile color, falling back to the theme color,
+// or an empty string when no tiles are generated
+func (c *Config) tileColor() string {
+	if !c.Windows {
+		return ""
+	}
+	if c.TileColor != "" {
+		return c.TileColor
+	}
+	if c.AppThemeColor != "" {
+		return c.AppThemeColor
+	}
+	return "#ffffff"
+}
+
+func runHTMLOnlyMode(config *Config) error {
+	htmlTags := generator.GenerateHTMLTags(config.buildHTMLTagsConfig())
+	fmt.Println(htmlTags)
+
+	if config.GenerateManifest {
+		manifestPath, err := generator.GenerateManifest(config.buildManifestConfig(), config.Output)
+		if err != nil {
+			return fmt.Errorf("failed to generate manifest: %w", err)
+		}
+		fmt.Printf("\n✓ Generated manifest: %s\n", manifestPath)
+	}
+
+	return nil
+}
+
+func validateSource(source string) error {
+	if source == "" {
+		return fmt.Errorf("source image is required (use --source or provide as first argument)")
+	}
+
+	if _, err := os.Stat(source); os.IsNotExist(err) {
+		return fmt.Errorf("source file does not exist: %s", source)
+	}
+
+	ext := strings.ToLower(filepath.Ext(source))
+	if ext != ".svg" && ext != ".png" && ext != ".ico" {
+		return fmt.Errorf("source must be SVG, PNG or ICO format, got: %s", ext)
+	}
+
+	return nil
+}
+
+// buildRequirements describes what the image processor must support to
+// handle the source and requested outputs
+func buildRequirements(config *Config) processor.Requirements {
+	req := processor.Requirements{
+		InputFormat:   processor.SourceFormat(config.Source),
+		OutputFormats: []string{"png"},
+	}
+
+	largest := 0
+	for _, target := range config.Targets {
+		largest = max(largest, target.Size.Max())
+	}
+
+	for _, size := range slices.Concat(config.appleTouchSizes(), config.androidSizes(), config.maskableSizes(), config.monochromeSizes()) {
+		largest = max(largest, size)
+	}
+
+	if config.Windows {
+		for _, tile := range generator.WindowsTiles {
+			largest = max(largest, tile.Size.Max())
+		}
+	}
+
+	// Splash screens only scale the source to the logo box
+	if config.Splash {
+		for _, screen := range generator.SplashScreens() {
+			side := screen.Size().Min()
+			largest = max(largest, side-2*generator.SplashPadding.Pixels(side))
+		}
+	}
+
+	if config.GenerateICO {
+		req.ICO = true
+		for _, size := range config.ICOSizes {
+			largest = max(largest, size)
+		}
+	}
+
+	// Raster sources smaller than the largest output have to be enlarged,
+	// unless upscaling was denied and they are padded instead. Cover and fill
+	// scale the shorter side up to the canvas, contain the longer one.
+	if width, height, ok := processor.SourceDimensions(config.Source); ok && config.Upscale {
+		limit := max(width, height)
+		if config.Fit == string(processor.FitCover) || config.Fit == string(processor.FitFill) {
+			limit = min(width, height)
+		}
+		req.Upscale = largest > limit
+	}
+
+	return req
+}
+
+// parseICOEncoding validates the ICO encoding; an empty value keeps the
+// backend's own ICO conversion
+func parseICOEncoding(encoding string) (processor.ICOEncoding, error) {
+	if encoding == "" {
+		return "", nil
+	}
+	return processor.ParseICOEncoding(encoding)
+}
+
+// parseResizeOptions builds the default resize options and the per-size
+// overrides from the config; empty values keep the defaults
+func parseResizeOptions(config *Config) (processor.ResizeOptions, map[processor.Size]processor.ResizeOptions, error) {
+	opts := processor.ResizeOptions{Upscale: config.Upscale}
+
+	if config.Fit != "" {
+		fit, err := processor.ParseFit(config.Fit)
+		if err != nil {
+			return opts, nil, err
+		}
+		opts.Fit = fit
+	}
+
+	if config.Gravity != "" {
+		gravity, err := processor.ParseGravity(config.Gravity)
+		if err != nil {
+			return opts, nil, err
+		}
+		opts.Gravity = gravity
+	}
+
+	paddings, err := parsePerSize(config.Padding, processor.ParsePadding)
+	if err != nil {
+		return opts, nil, fmt.Errorf("invalid padding: %w", err)
+	}
+	backgrounds, err := parsePerSize(config.Background, processor.ParseColor)
+	if err != nil {
+		return opts, nil, fmt.Errorf("invalid background: %w", err)
+	}
+
+	if padding, ok := paddings[processor.Size{}]; ok {
+		opts.Padding = padding
+	}
+	if background, ok := backgrounds[processor.Size{}]; ok {
+		opts.Background = background
+	}
+
+	perSize := make(map[processor.Size]processor.ResizeOptions)
+	for size, padding := range paddings {
+		if size != (processor.Size{}) {
+			o := opts
+			o.Padding = padding
+			perSize[size] = o
+		}
+	}
+	for size, background := range backgrounds {
+		if size != (processor.Size{}) {
+			o, ok := perSize[size]
+			if !ok {
+				o = opts
+			}
+			o.Background = background
+			perSize[size] = o
+		}
+	}
+
+	return opts, perSize, nil
+}
+
+// parsePerSize parses a comma-separated list of values where plain entries
+// set the default and SIZE=VALUE entries override it for one size, given as
+// N or WxH. The default is stored under the zero Size.
+func parsePerSize[T any](spec string, parse func(string) (T, error)) (map[processor.Size]T, error) {
+	values := make(map[processor.Size]T)
+	if strings.TrimSpace(spec) == "" {
+		return values, nil
+	}
+
+	for _, part := range strings.Split(spec, ",") {
+		var size processor.Size
+		raw := strings.TrimSpace(part)
+		if sizeStr, value, ok := strings.Cut(raw, "="); ok {
+			n, err := processor.ParseSize(sizeStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid size %q in %q", sizeStr, part)
+			}
+			size, raw = n, strings.TrimSpace(value)
+		}
+
+		v, err := parse(raw)
+		if err != nil {
+			return nil, err
+		}
+		values[size] = v
+	}
+
+	return values, nil
+}
+
+// parseAppleTouchOptions derives the apple-touch-icon options from the
+// default resize options. iOS renders transparency as black, so the icon is
+// flattened onto the app background color.
+func parseAppleTouchOptions(config *Config, base processor.ResizeOptions) (processor.ResizeOptions, error) {
+	opts := base
+	if !config.AppleTouchIcon {
+		return opts, nil
+	}
+
+	padding := processor.Padding{}
+	if config.AppleTouchPadding != "" {
+		var err error
+		if padding, err = processor.ParsePadding(config.AppleTouchPadding); err != nil {
+			return opts, fmt.Errorf("invalid apple-touch-icon padding: %w", err)
+		}
+	}
+	opts.Padding = padding
+
+	background, err := parseAppBackground(config)
+	if err != nil {
+		return opts, fmt.Errorf("invalid app background color for apple-touch-icon: %w", err)
+	}
+	opts.Background = background
+
+	return opts, nil
+}
+
+// parseMaskableOptions derives the maskable icon options from the default
+// resize options: the source is padded into the safe zone and the canvas is
+// filled with the app background color.
+func parseMaskableOptions(config *Config, base processor.ResizeOptions) (processor.ResizeOptions, error) {
+	opts := base
+	if len(config.maskableSizes()) == 0 {
+		return opts, nil
+	}
+
+	background, err := parseAppBackground(config)
+	if err != nil {
+		return opts, fmt.Errorf("invalid app background color for maskable icons: %w", err)
+	}
+	opts.Padding = generator.MaskablePadding()
+	opts.Background = background
+
+	return opts, nil
+}
+
+// parseMonochromeOptions derives the monochrome icon options from the default
+// resize options. Silhouettes are always transparent around the shape.
+func parseMonochromeOptions(config *Config, base processor.ResizeOptions) (processor.ResizeOptions, error) {
+	opts := base
+	opts.Monochrome = true
+	opts.Background = nil
+
+	if config.MonochromeThreshold != "" {
+		threshold, err := processor.ParseThreshold(config.MonochromeThreshold)
+		if err != nil {
+			return opts, fmt.Errorf("invalid monochrome threshold: %w", err)
+		}
+		opts.Threshold = threshold
+	}
+
+	return opts, nil
+}
+
+// parseSplashOptions derives the splash screen options from the default
+// resize options: the whole logo is centered on the app background color.
+func parseSplashOptions(config *Config, base processor.ResizeOptions) (processor.ResizeOptions, error) {
+	opts := base
+	if !config.Splash {
+		return opts, nil
+	}
+
+	background, err := parseAppBackground(config)
+	if err != nil {
+		return opts, fmt.Errorf("invalid app background color for splash screens: %w", err)
+	}
+	opts.Fit = processor.FitContain
+	opts.Gravity = processor.GravityCenter
+	opts.Padding = generator.SplashPadding
+	opts.Background = background
+
+	return opts, nil
+}
+
+// parseSVGDarkColors builds the favicon.svg dark mode colors from the config
+func parseSVGDarkColors(config *Config) (generator.SVGDarkColors, error) {
+	dark := generator.SVGDarkColors{Fill: strings.TrimSpace(config.SVGDarkFill)}
+	if dark.Fill != "" {
+		if err := generator.ValidateSVGColor(dark.Fill); err != nil {
+			return dark, fmt.Errorf("invalid SVG dark fill: %w", err)
+		}
+	}
+
+	if strings.TrimSpace(config.SVGDarkColors) == "" {
+		return dark, nil
+	}
+	for _, part := range strings.Split(config.SVGDarkColors, ",") {
+		from, to, ok := strings.Cut(part, "=")
+		if !ok {
+			return dark, fmt.Errorf("invalid SVG dark color %q (expected FROM=TO)", part)
+		}
+		r := generator.ColorReplacement{From: strings.TrimSpace(from), To: strings.TrimSpace(to)}
+		for _, c := range []string{r.From, r.To} {
+			if err := generator.ValidateSVGColor(c); err != nil {
+				return dark, fmt.Errorf("invalid SVG dark color: %w", err)
+			}
+		}
+		dark.Replace = append(dark.Replace, r)
+	}
+
+	return dark, nil
+}
+
+// parseAppBackground parses the app background color used for opaque icons;
+// an empty color is transparent
+func parseAppBackground(config *Config) (color.Color, error) {
+	if config.AppBackgroundColor == "" {
+		return nil, nil
+	}
+	return processor.ParseColor(config.AppBackgroundColor)
+}
+
+// windowsTileOptions derives the tile options from the default resize
+// options; Windows draws tiles on the tile color, so they stay transparent
+func windowsTileOptions(base processor.ResizeOptions) processor.ResizeOptions {
+	opts := base
+	opts.Background = nil
+	return opts
+}
+
+func generateWindowsFiles(ctx context.Context, gen *generator.FaviconGenerator, config *Config) error {
+	paths, err := gen.GenerateWindowsTiles(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to generate windows tiles: %w", err)
+	}
+	fmt.Printf("✓ Generated %d windows tile files\n", len(paths))
+
+	browserConfigPath, err := generator.GenerateBrowserConfig(&generator.BrowserConfigConfig{
+		TileColor: config.tileColor(),
+		Names:     config.names(),
+	}, config.Output)
+	if err != nil {
+		return fmt.Errorf("failed to generate browserconfig: %w", err)
+	}
+	fmt.Printf("✓ Generated browserconfig: %s\n", browserConfigPath)
+
+	return nil
+}
+
+// generateICOFile writes favicon.ico and returns its path, or an empty path
+// when no ICO sizes are configured or generation failed
+func generateICOFile(ctx context.Context, gen *generator.FaviconGenerator) string {
+	if len(gen.ICOSizes) == 0 {
+		return ""
+	}
+
+	icoPath, err := gen.GenerateICO(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to generate ICO file: %v\n", err)
+		return ""
+	}
+	fmt.Printf("✓ Generated favicon.ico: %s\n", icoPath)
+	return icoPath
+}
+
+// generateSVGFaviconFile writes favicon.svg and returns its path, or an
+// empty path when the source could not be converted
+func generateSVGFaviconFile(gen *generator.FaviconGenerator) string {
+	path, err := gen.GenerateSVGFavicon()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to generate SVG favicon: %v\n", err)
+		return ""
+	}
+	fmt.Printf("✓ Generated SVG favicon: %s\n", path)
+	return path
+}
+
+func generateHTMLTagsFile(tags *generator.HTMLTagsConfig, output string) error {
+	htmlTags := generator.GenerateHTMLTags(tags)
+
+	htmlPath := filepath.Join(output, "favicon-tags.html")
+	if err := os.WriteFile(htmlPath, []byte(htmlTags), 0644); err != nil {
+		return fmt.Errorf("failed to write HTML tags: %w", err)
+	}
+
+	fmt.Printf("✓ Generated HTML tags: %s\n", htmlPath)
+	fmt.Println("\nHTML tags to include in your <head>:")
+	fmt.Println(strings.Repeat("-", 50))
+	fmt.Println(htmlTags)
+	fmt.Println(strings.Repeat("-", 50))
+
+	return nil
+}
+
+func run(ctx context.Context, config *Config) error {
+	if _, err := generator.ParseNameTemplate(config.NameTemplate); err != nil {
+		return err
+	}
+
+	if config.GenerateHTMLOnly {
+		return runHTMLOnlyMode(config)
+	}
+
+	if err := validateSource(config.Source); err != nil {
+		return err
+	}
+
+	if config.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative: %s", config.Timeout)
+	}
+
+	if config.Jobs < 0 {
+		return fmt.Errorf("jobs must not be negative: %d", config.Jobs)
+	}
+
+	if config.MaskIcon && processor.SourceFormat(config.Source) != "svg" {
+		return fmt.Errorf("--mask-icon requires an SVG source")
+	}
+
+	icoEncoding, err := parseICOEncoding(config.ICOEncoding)
+	if err != nil {
+		return err
+	}
+
+	resizeOpts, sizeOpts, err := parseResizeOptions(config)
+	if err != nil {
+		return err
+	}
+
+	appleTouchOpts, err := parseAppleTouchOptions(config, resizeOpts)
+	if err != nil {
+		return err
+	}
+
+	maskableOpts, err := parseMaskableOptions(config, resizeOpts)
+	if err != nil {
+		return err
+	}
+
+	monochromeOpts, err := parseMonochromeOptions(config, resizeOpts)
+	if err != nil {
+		return err
+	}
+
+	splashOpts, err := parseSplashOptions(config, resizeOpts)
+	if err != nil {
+		return err
+	}
+
+	svgDarkColors, err := parseSVGDarkColors(config)
+	if err != nil {
+		return err
+	}
+
+	proc, err := processor.DetectAvailableProcessor(config.Backend, buildRequirements(config))
+	if err != nil {
+		return fmt.Errorf("failed to initialize image processor: %w", err)
+	}
+
+	fmt.Printf("Using %s for image processing\n", proc.Name())
+	fmt.Printf("Source: %s\n", config.Source)
+	fmt.Printf("Output: %s\n", config.Output)
+	fmt.Printf("Sizes: %v\n", config.Targets)
+
+	gen := &generator.FaviconGenerator{
+		Processor:          proc,
+		SourcePath:         config.Source,
+		OutputDir:          config.Output,
+		Targets:            config.Targets,
+		Names:              config.names(),
+		ResizeOptions:      resizeOpts,
+		SizeOptions:        sizeOpts,
+		AppleTouchSizes:    config.appleTouchSizes(),
+		AppleTouchOptions:  appleTouchOpts,
+		AppleTouchRoot:     config.AppleTouchRoot,
+		AndroidSizes:       config.androidSizes(),
+		AndroidOptions:     resizeOpts,
+		MaskableSizes:      config.maskableSizes(),
+		MaskableOptions:    maskableOpts,
+		MonochromeSizes:    config.monochromeSizes(),
+		MonochromeOptions:  monochromeOpts,
+		SVGDarkColors:      svgDarkColors,
+		WindowsTileOptions: windowsTileOptions(resizeOpts),
+		SplashOptions:      splashOpts,
+		ICOSizes:           config.ICOSizes,
+		ICOEncoding:        icoEncoding,
+		Jobs:               config.Jobs,
+		Timeout:            config.Timeout,
+	}
+
+	if err := gen.CheckFileNames(config.Windows, config.Splash); err != nil {
+		return err
+	}
+
+	result, err := gen.Generate(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to generate favicons: %w", err)
+	}
+
+	fmt.Printf("\n✓ Generated %d favicon files\n", len(result.GeneratedFiles))
+
+	icoPath := ""
+	if config.GenerateICO {
+		icoPath = generateICOFile(ctx, gen)
+	}
+
+	if len(gen.AppleTouchSizes) > 0 {
+		paths, err := gen.GenerateAppleTouchIcons(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to generate apple-touch-icons: %w", err)
+		}
+		fmt.Printf("✓ Generated %d apple-touch-icon files\n", len(paths))
+	}
+
+	if len(gen.AndroidSizes) > 0 {
+		paths, err := gen.GenerateAndroidChromeIcons(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to generate android-chrome icons: %w", err)
+		}
+		fmt.Printf("✓ Generated %d android-chrome icon files\n", len(paths))
+	}
+
+	if len(gen.MaskableSizes) > 0 {
+		paths, err := gen.GenerateMaskableIcons(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to generate maskable icons: %w", err)
+		}
+		fmt.Printf("✓ Generated %d maskable icon files\n", len(paths))
+	}
+
+	if len(gen.MonochromeSizes) > 0 {
+		paths, err := gen.GenerateMonochromeIcons(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to generate monochrome icons: %w", err)
+		}
+		fmt.Printf("✓ Generated %d monochrome icon files\n", len(paths))
+	}
+
+	if config.Windows {
+		if err := generateWindowsFiles(ctx, gen, config); err != nil {
+			return err
+		}
+	}
+
+	if config.Splash {
+		paths, err := gen.GenerateSplashScreens(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to generate splash screens: %w", err)
+		}
+		fmt.Printf("✓ Generated %d splash screen files\n", len(paths))
+	}
+
+	svgPath := ""
+	if config.svgFavicon() {
+		svgPath = generateSVGFaviconFile(gen)
+	}
+
+	if config.MaskIcon {
+		path, err := gen.GenerateSafariPinnedTab()
+		if err != nil {
+			return fmt.Errorf("failed to generate safari pinned tab: %w", err)
+		}
+		fmt.Printf("✓ Generated safari pinned tab: %s\n", path)
+	}
+
+	if config.GenerateManifest {
+		manifestPath, err := generator.GenerateManifest(config.buildManifestConfig(), config.Output)
+		if err != nil {
+			return fmt.Errorf("failed to generate manifest: %w", err)
+		}
+		fmt.Printf("✓ Generated manifest: %s\n", manifestPath)
+	}
+
+	if config.GenerateHTML {
+		tags := config.buildHTMLTagsConfig()
+		tags.IncludeICO = icoPath != ""
+		tags.IncludeSVG = svgPath != ""
+		if err := generateHTMLTagsFile(tags, config.Output); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func parseSizes(sizesStr string) ([]int, error) {
+	parts := strings.Split(sizesStr, ",")
+	sizes := make([]int, 0, len(parts))
+
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		size, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid size: %s", part)
+		}
+		if size <= 0 {
+			return nil, fmt.Errorf("size must be positive: %d", size)
+		}
+		sizes = append(sizes, size)
+	}
+
+	return sizes, nil
+}
+
+// parseTargets parses the favicon target list. Each entry is a square edge
+// length or a WxH rectangle, optionally followed by colon-separated fields:
+// a file name and padding=VALUE or background=VALUE overrides, e.g.
+// 180:apple-touch-icon.png:background=#ffffff.
+func parseTargets(spec string) ([]generator.Target, error) {
+	parts := strings.Split(spec, ",")
+	targets := make([]generator.Target, 0, len(parts))
+
+	for _, part := range parts {
+		target, err := parseTarget(part)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, target)
+	}
+
+	return targets, nil
+}
+
+func parseTarget(spec string) (generator.Target, error) {
+	fields := strings.Split(spec, ":")
+	size, err := processor.ParseSize(fields[0])
+	if err != nil {
+		return generator.Target{}, err
+	}
+
+	target := generator.Target{Size: size}
+	for _, field := range fields[1:] {
+		field = strings.TrimSpace(field)
+		key, value, ok := strings.Cut(field, "=")
+		switch {
+		case !ok:
+			if target.Name != "" {
+				return generator.Target{}, fmt.Errorf("more than one file name in %q", spec)
+			}
+			if err := validateTargetName(field); err != nil {
+				return generator.Target{}, err
+			}
+			target.Name = field
+		case key == "padding":
+			padding, err := processor.ParsePadding(value)
+			if err != nil {
+				return generator.Target{}, fmt.Errorf("invalid padding in %q: %w", spec, err)
+			}
+			target.Padding = &padding
+		case key == "background":
+			background, err := processor.ParseColor(value)
+			if err != nil {
+				return generator.Target{}, fmt.Errorf("invalid background in %q: %w", spec, err)
+			}
+			target.Background = &background
+		default:
+			return generator.Target{}, fmt.Errorf("unknown option %q in %q (expected padding or background)", key, spec)
+		}
+	}
+
+	return target, nil
+}
+
+// validateTargetName checks that a target file name is a bare name or a .png
+// path inside the output directory
+func validateTargetName(name string) error {
+	if name == "" {
+		return fmt.Errorf("empty file name")
+	}
+
+	clean := path.Clean(name)
+	if strings.Contains(name, `\`) || path.IsAbs(clean) || clean == "." || clean == ".." || strings.HasPrefix(clean, "../") {
+		return fmt.Errorf("file name %q must be a relative path inside the output directory", name)
+	}
+	if ext := path.Ext(clean); ext != "" && ext != ".png" {
+		return fmt.Errorf("file name %q must end in .png", name)
+	}
+
+	return nil
+}
+
+// parseICOSizes parses the ICO size list; ICO entries cannot exceed 256px
+// and each size may appear only once
+func parseICOSizes(sizesStr string) ([]int, error) {
+	sizes, err := parseSizes(sizesStr)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, size := range sizes {
+		if size > 256 {
+			return nil, fmt.Errorf("ICO size must be at most 256: %d", size)
+		}
+		if slices.Contains(sizes[:i], size) {
+			return nil, fmt.Errorf("duplicate ICO size: %d", size)
+		}
+	}
+
+	return sizes, nil
+}
+
+func showUsage() {
+	fmt.Printf("favicongen v%s - Generate favicon files from a single image\n\n", Version)
+	fmt.Println("Usage:")
+	fmt.Println("  favicongen --source <image> --output <dir> [options]")
+	fmt.Println("  favicongen <image> <dir>                (shorthand)")
+	fmt.Println("  favicongen inspect [--json] <file.ico>  (list images in an ICO file)")
+	fmt.Println("  favicongen extract <file.ico> [dir]     (unpack ICO images to PNG files)")
+	fmt.Println("  favicongen version                      (show version)")
+	fmt.Println("  favicongen help                         (show this help)")
+	fmt.Println()
+	fmt.Println("General Options:")
+	fmt.Println("  --source <path>          Source image file (SVG, PNG or ICO)")
+	fmt.Println("  --output <dir>           Output directory (default: ./favicons)")
+	fmt.Println("  --sizes <sizes>          Comma-separated sizes as N or WxH (default: 16,32,48,64,128,180,256,512)")
+	fmt.Println("                           each optionally followed by :NAME, :padding=VALUE or :background=VALUE")
+	fmt.Println("  --name-template <tmpl>   File name template with {name}, {w}, {h}, {size}, {ext} (default: {name}-{w}x{h}.{ext})")
+	fmt.Println("  --preset <name>          Output preset, see Presets below; explicit flags override it")
+	fmt.Printf("  --backend <name>         Image processor: %s (auto-detect if not specified)\n", strings.Join(processor.Registered(), ", "))
+	fmt.Println("  --jobs <n>               Concurrent resize operations (default: GOMAXPROCS)")
+	fmt.Println("  --upscale                Enlarge sources smaller than an output size (default: true)")
+	fmt.Println("  --fit <mode>             Scaling mode: contain, cover or fill (default: contain)")
+	fmt.Println("  --gravity <anchor>       Anchor for padding and cropping: center, north, south, east, west,")
+	fmt.Println("                           northeast, northwest, southeast, southwest (default: center)")
+	fmt.Println("  --padding <spec>         Margin in px or %, per-size as SIZE=VALUE (default: 0)")
+	fmt.Println("  --background <spec>      Canvas color or transparent, per-size as SIZE=VALUE (default: transparent)")
+	fmt.Println("  --timeout <duration>     Timeout per image operation, 0 disables (default: 1m)")
+	fmt.Println("  --html-tags              Generate HTML tags file (default: true)")
+	fmt.Println("  --manifest               Generate manifest.webmanifest (default: false)")
+	fmt.Println("  --ico                    Generate favicon.ico (default: true)")
+	fmt.Println("  --ico-sizes <sizes>      Comma-separated sizes bundled into favicon.ico (default: 16,32,48)")
+	fmt.Println("  --ico-encoding <mode>    ICO entry encoding: png, bmp or legacy (default: backend)")
+	fmt.Println("  --apple-touch-icon       Generate opaque apple-touch-icon.png (default: true)")
+	fmt.Println("  --apple-touch-sizes      Comma-separated apple-touch-icon sizes (default: 180)")
+	fmt.Println("  --apple-touch-padding    Margin around the apple-touch-icon in px or % (default: 0)")
+	fmt.Println("  --apple-touch-root       Write the 180px icon as /apple-touch-icon.png, ignoring --name-template (default: true)")
+	fmt.Println("  --android                Generate android-chrome icons with --manifest (default: true)")
+	fmt.Println("  --android-sizes          Comma-separated android-chrome icon sizes (default: 192,512)")
+	fmt.Println("  --maskable               Generate maskable icons with --manifest (default: true)")
+	fmt.Println("  --maskable-sizes         Comma-separated maskable icon sizes (default: 192,512)")
+	fmt.Println("  --monochrome             Generate monochrome silhouette icons (default: false)")
+	fmt.Println("  --monochrome-sizes       Comma-separated monochrome icon sizes (default: 192,512)")
+	fmt.Println("  --monochrome-threshold   Alpha cutoff from 0 to below 1, 0 keeps soft edges (default: 0.5)")
+	fmt.Println("  --mask-icon              Generate safari-pinned-tab.svg from an SVG source (default: false)")
+	fmt.Println("  --mask-icon-color        Safari pinned tab color (default: #000000)")
+	fmt.Println("  --svg                    Write favicon.svg when the source is SVG (default: true)")
+	fmt.Println("  --svg-dark-fill          Default fill of favicon.svg in dark mode")
+	fmt.Println("  --svg-dark-colors        FROM=TO color swaps for favicon.svg in dark mode")
+	fmt.Println("  --windows                Generate mstile-*.png and browserconfig.xml (default: false)")
+	fmt.Println("  --tile-color             Windows tile color (default: app theme color)")
+	fmt.Println("  --splash                 Generate iOS splash screens in splash/ (default: false)")
+	fmt.Println("  --generate-html-tags     Only generate HTML tags from existing favicons")
+	fmt.Println()
+	fmt.Println("Manifest Options:")
+	fmt.Println("  --app-name <name>               Application name")
+	fmt.Println("  --app-short-name <name>         Short application name")
+	fmt.Println("  --app-description <text>        Application description")
+	fmt.Println("  --app-start-url <url>           Start URL (default: /)")
+	fmt.Println("  --app-display <mode>            Display mode (default: standalone)")
+	fmt.Println("  --app-orientation <mode>        Orientation (default: any)")
+	fmt.Println("  --app-scope <path>              Scope (default: /)")
+	fmt.Println("  --app-theme-color <color>       Theme color (default: #ffffff)")
+	fmt.Println("  --app-background-color <color>  Background color (default: #ffffff)")
+	fmt.Println("  --app-categories <list>         Comma-separated categories")
+	fmt.Println("  --app-icon <path>               Icon path")
+	fmt.Println()
+	fmt.Println("Presets:")
+	for _, name := range presetNames() {
+		fmt.Printf("  %-24s %s\n", name, presets[name].description)
+	}
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  favicongen logo.svg ./public/favicons")
+	fmt.Println("  favicongen --source logo.png --output ./dist --sizes 16,32,64")
+	fmt.Println("  favicongen --source banner.png --fit cover --gravity west")
+	fmt.Println("  favicongen --source logo.svg --padding 0,180=12% --background transparent,180=#ffffff")
+	fmt.Println("  favicongen --source logo.svg --manifest --app-name \"My App\"")
+	fmt.Println("  favicongen --source logo.svg --preset pwa --app-background-color \"#0f172a\"")
+	fmt.Println("  favicongen --generate-html-tags --output ./public --sizes 16,32,64")
+	fmt.Println("  favicongen inspect --json ./public/favicon.ico")
+	fmt.Println("  favicongen extract legacy/favicon.ico ./extracted")
+}
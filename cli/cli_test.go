@@ -0,0 +1,1123 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fathurrohman26/favicongen/internal/generator"
+	"github.com/fathurrohman26/favicongen/processor"
+)
+
+func TestParseSizes(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []int
+		wantErr bool
+	}{
+		{
+			name:    "single size",
+			input:   "16",
+			want:    []int{16},
+			wantErr: false,
+		},
+		{
+			name:    "multiple sizes",
+			input:   "16,32,48",
+			want:    []int{16, 32, 48},
+			wantErr: false,
+		},
+		{
+			name:    "sizes with spaces",
+			input:   "16, 32, 48",
+			want:    []int{16, 32, 48},
+			wantErr: false,
+		},
+		{
+			name:    "default sizes",
+			input:   "16,32,48,64,128,180,256,512",
+			want:    []int{16, 32, 48, 64, 128, 180, 256, 512},
+			wantErr: false,
+		},
+		{
+			name:    "invalid size - not a number",
+			input:   "16,abc,48",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "invalid size - negative",
+			input:   "16,-32,48",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "invalid size - zero",
+			input:   "16,0,48",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "empty string",
+			input:   "",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSizes(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseSizes(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				if len(got) != len(tt.want) {
+					t.Errorf("parseSizes(%q) = %v, want %v", tt.input, got, tt.want)
+					return
+				}
+				for i := range got {
+					if got[i] != tt.want[i] {
+						t.Errorf("parseSizes(%q)[%d] = %d, want %d", tt.input, i, got[i], tt.want[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestParseTargets(t *testing.T) {
+	got, err := parseTargets("16, 32,310x150")
+	if err != nil {
+		t.Fatalf("parseTargets() error = %v", err)
+	}
+	want := generator.SizeTargets(processor.Square(16), processor.Square(32), processor.Size{Width: 310, Height: 150})
+	if !slices.Equal(got, want) {
+		t.Errorf("parseTargets() = %v, want %v", got, want)
+	}
+
+	for _, input := range []string{"16,0x32", "16,x", "", "310x150x2"} {
+		if _, err := parseTargets(input); err == nil {
+			t.Errorf("parseTargets(%q) expected error", input)
+		}
+	}
+}
+
+func TestParseTargetsNamesAndOverrides(t *testing.T) {
+	got, err := parseTargets("16,180:apple-touch-icon.png:padding=12%:background=#ffffff,192:android-chrome:background=transparent,64:padding=2")
+	if err != nil {
+		t.Fatalf("parseTargets() error = %v", err)
+	}
+	if len(got) != 4 {
+		t.Fatalf("got %d targets, want 4: %v", len(got), got)
+	}
+
+	if got[0] != (generator.Target{Size: processor.Square(16)}) {
+		t.Errorf("targets[0] = %+v, want plain 16x16", got[0])
+	}
+
+	apple := got[1]
+	if apple.Size != processor.Square(180) || apple.Name != "apple-touch-icon.png" {
+		t.Errorf("targets[1] = %v, want 180x180:apple-touch-icon.png", apple)
+	}
+	if apple.Padding == nil || *apple.Padding != (processor.Padding{Value: 12, Percent: true}) {
+		t.Errorf("targets[1].Padding = %v, want 12%%", apple.Padding)
+	}
+	if apple.Background == nil || *apple.Background != (color.NRGBA{R: 255, G: 255, B: 255, A: 255}) {
+		t.Errorf("targets[1].Background = %v, want #ffffff", apple.Background)
+	}
+
+	android := got[2]
+	if android.Name != "android-chrome" || android.Padding != nil {
+		t.Errorf("targets[2] = %+v, want android-chrome without padding", android)
+	}
+	if android.Background == nil || *android.Background != nil {
+		t.Errorf("targets[2].Background = %v, want explicit transparent", android.Background)
+	}
+
+	if padded := got[3]; padded.Name != "" || padded.Padding == nil || *padded.Padding != (processor.Padding{Value: 2}) || padded.Background != nil {
+		t.Errorf("targets[3] = %+v, want unnamed 64x64 with 2px padding", padded)
+	}
+}
+
+func TestParseTargetsErrors(t *testing.T) {
+	for _, input := range []string{
+		"180:",
+		"180:a.png:b.png",
+		"180:icon.jpg",
+		"180:/abs/icon.png",
+		"180:../icon.png",
+		`180:icons\icon.png`,
+		"180:padding=wide",
+		"180:background=white",
+		"180:margin=2",
+	} {
+		if _, err := parseTargets(input); err == nil {
+			t.Errorf("parseTargets(%q) expected error", input)
+		}
+	}
+}
+
+func TestParseICOSizes(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []int
+		wantErr bool
+	}{
+		{name: "default sizes", input: "16,32,48", want: []int{16, 32, 48}},
+		{name: "extended sizes", input: "16,24,32,48,64,256", want: []int{16, 24, 32, 48, 64, 256}},
+		{name: "too large", input: "16,512", wantErr: true},
+		{name: "duplicate size", input: "16,16,32", wantErr: true},
+		{name: "invalid size", input: "16,abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseICOSizes(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseICOSizes(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("parseICOSizes(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseICOEncoding(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "", want: ""},
+		{input: "png", want: "png"},
+		{input: "bmp", want: "bmp"},
+		{input: "legacy", want: "legacy"},
+		{input: "gif", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseICOEncoding(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseICOEncoding(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("parseICOEncoding(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigDefaults(t *testing.T) {
+	config := &Config{
+		Source:             "",
+		Output:             "./favicons",
+		Targets:            generator.SizeTargets(processor.Squares(16, 32, 48, 64, 128, 180, 256, 512)...),
+		Backend:            "",
+		GenerateHTML:       true,
+		GenerateManifest:   false,
+		GenerateICO:        true,
+		GenerateHTMLOnly:   false,
+		AppName:            "",
+		AppShortName:       "",
+		AppDescription:     "",
+		AppStartURL:        "/",
+		AppDisplay:         "standalone",
+		AppOrientation:     "any",
+		AppScope:           "/",
+		AppThemeColor:      "#ffffff",
+		AppBackgroundColor: "#ffffff",
+		AppCategories:      nil,
+		AppIcon:            "",
+	}
+
+	if config.Output != "./favicons" {
+		t.Errorf("default Output = %q, want %q", config.Output, "./favicons")
+	}
+	if !config.GenerateHTML {
+		t.Error("default GenerateHTML should be true")
+	}
+	if config.GenerateManifest {
+		t.Error("default GenerateManifest should be false")
+	}
+	if !config.GenerateICO {
+		t.Error("default GenerateICO should be true")
+	}
+	if config.AppStartURL != "/" {
+		t.Errorf("default AppStartURL = %q, want %q", config.AppStartURL, "/")
+	}
+	if config.AppDisplay != "standalone" {
+		t.Errorf("default AppDisplay = %q, want %q", config.AppDisplay, "standalone")
+	}
+	if config.AppOrientation != "any" {
+		t.Errorf("default AppOrientation = %q, want %q", config.AppOrientation, "any")
+	}
+	if config.AppThemeColor != "#ffffff" {
+		t.Errorf("default AppThemeColor = %q, want %q", config.AppThemeColor, "#ffffff")
+	}
+	if config.AppBackgroundColor != "#ffffff" {
+		t.Errorf("default AppBackgroundColor = %q, want %q", config.AppBackgroundColor, "#ffffff")
+	}
+}
+
+func TestConfigSizesContains180(t *testing.T) {
+	defaultSizes := []int{16, 32, 48, 64, 128, 180, 256, 512}
+
+	found := slices.Contains(defaultSizes, 180)
+
+	if !found {
+		t.Error("default sizes should include 180 for Apple Touch Icon")
+	}
+}
+
+func TestVersionVariables(t *testing.T) {
+	if Version == "" {
+		t.Error("Version should not be empty")
+	}
+	if BuildDate == "" {
+		t.Error("BuildDate should not be empty")
+	}
+	if CommitHash == "" {
+		t.Error("CommitHash should not be empty")
+	}
+}
+
+func TestParseCategories(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{
+			name:  "empty string",
+			input: "",
+			want:  nil,
+		},
+		{
+			name:  "single category",
+			input: "utilities",
+			want:  []string{"utilities"},
+		},
+		{
+			name:  "multiple categories",
+			input: "utilities,productivity,tools",
+			want:  []string{"utilities", "productivity", "tools"},
+		},
+		{
+			name:  "categories with spaces",
+			input: "utilities, productivity, tools",
+			want:  []string{"utilities", "productivity", "tools"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseCategories(tt.input)
+			if tt.want == nil && got != nil {
+				t.Errorf("parseCategories(%q) = %v, want nil", tt.input, got)
+				return
+			}
+			if tt.want != nil {
+				if len(got) != len(tt.want) {
+					t.Errorf("parseCategories(%q) = %v, want %v", tt.input, got, tt.want)
+					return
+				}
+				for i := range got {
+					if got[i] != tt.want[i] {
+						t.Errorf("parseCategories(%q)[%d] = %q, want %q", tt.input, i, got[i], tt.want[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestValidateSource(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "favicongen-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// Create test files
+	pngFile := filepath.Join(tmpDir, "test.png")
+	svgFile := filepath.Join(tmpDir, "test.svg")
+	icoFile := filepath.Join(tmpDir, "test.ico")
+	txtFile := filepath.Join(tmpDir, "test.txt")
+
+	for _, f := range []string{pngFile, svgFile, icoFile, txtFile} {
+		if err := os.WriteFile(f, []byte("test"), 0644); err != nil {
+			t.Fatalf("failed to create test file: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		source  string
+		wantErr bool
+	}{
+		{
+			name:    "empty source",
+			source:  "",
+			wantErr: true,
+		},
+		{
+			name:    "non-existent file",
+			source:  "/nonexistent/file.png",
+			wantErr: true,
+		},
+		{
+			name:    "valid PNG file",
+			source:  pngFile,
+			wantErr: false,
+		},
+		{
+			name:    "valid SVG file",
+			source:  svgFile,
+			wantErr: false,
+		},
+		{
+			name:    "valid ICO file",
+			source:  icoFile,
+			wantErr: false,
+		},
+		{
+			name:    "invalid format",
+			source:  txtFile,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSource(tt.source)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateSource(%q) error = %v, wantErr %v", tt.source, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildManifestConfig(t *testing.T) {
+	config := &Config{
+		AppName:            "Test App",
+		AppShortName:       "Test",
+		AppDescription:     "A test application",
+		AppStartURL:        "/app",
+		AppDisplay:         "fullscreen",
+		AppOrientation:     "portrait",
+		AppScope:           "/app/",
+		AppThemeColor:      "#ff0000",
+		AppBackgroundColor: "#00ff00",
+		AppCategories:      []string{"utilities"},
+		AppIcon:            "icon.png",
+		Targets:            generator.SizeTargets(processor.Squares(192, 512)...),
+	}
+
+	manifestConfig := config.buildManifestConfig()
+
+	if manifestConfig.Name != config.AppName {
+		t.Errorf("Name = %q, want %q", manifestConfig.Name, config.AppName)
+	}
+	if manifestConfig.ShortName != config.AppShortName {
+		t.Errorf("ShortName = %q, want %q", manifestConfig.ShortName, config.AppShortName)
+	}
+	if manifestConfig.Display != config.AppDisplay {
+		t.Errorf("Display = %q, want %q", manifestConfig.Display, config.AppDisplay)
+	}
+	if manifestConfig.ThemeColor != config.AppThemeColor {
+		t.Errorf("ThemeColor = %q, want %q", manifestConfig.ThemeColor, config.AppThemeColor)
+	}
+	if len(manifestConfig.Targets) != len(config.Targets) {
+		t.Errorf("Targets length = %d, want %d", len(manifestConfig.Targets), len(config.Targets))
+	}
+}
+
+func TestConfigAndroidSizes(t *testing.T) {
+	config := &Config{Android: true, AndroidSizes: []int{192, 512}, GenerateManifest: true}
+	if got := config.buildManifestConfig().AndroidSizes; !slices.Equal(got, []int{192, 512}) {
+		t.Errorf("manifest AndroidSizes = %v, want [192 512]", got)
+	}
+
+	config.GenerateManifest = false
+	if sizes := config.androidSizes(); sizes != nil {
+		t.Errorf("androidSizes() = %v without manifest, want nil", sizes)
+	}
+
+	config.GenerateManifest, config.Android = true, false
+	if sizes := config.androidSizes(); sizes != nil {
+		t.Errorf("androidSizes() = %v when disabled, want nil", sizes)
+	}
+}
+
+func TestBuildHTMLTagsConfig(t *testing.T) {
+	config := &Config{
+		Targets:          generator.SizeTargets(processor.Squares(16, 32, 64)...),
+		GenerateManifest: true,
+		AppThemeColor:    "#123456",
+	}
+
+	htmlConfig := config.buildHTMLTagsConfig()
+
+	if len(htmlConfig.Targets) != len(config.Targets) {
+		t.Errorf("Targets length = %d, want %d", len(htmlConfig.Targets), len(config.Targets))
+	}
+	if htmlConfig.IncludeManifest != config.GenerateManifest {
+		t.Errorf("IncludeManifest = %v, want %v", htmlConfig.IncludeManifest, config.GenerateManifest)
+	}
+	if htmlConfig.ThemeColor != config.AppThemeColor {
+		t.Errorf("ThemeColor = %q, want %q", htmlConfig.ThemeColor, config.AppThemeColor)
+	}
+}
+
+func TestParseConfigHTMLOnlyAppleTouchIcon(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		want        string
+		wantMissing bool
+	}{
+		{name: "no large target", args: []string{"--sizes", "16,32,64"}, wantMissing: true},
+		{name: "fallback to a large target", args: []string{"--sizes", "16,32,192"}, want: `<link rel="apple-touch-icon" sizes="192x192" href="/favicon-192x192.png">`},
+		{name: "explicit apple-touch-icon", args: []string{"--sizes", "16,32,64", "--apple-touch-icon"}, want: `<link rel="apple-touch-icon" sizes="180x180" href="/apple-touch-icon.png">`},
+		{name: "explicit apple-touch-sizes", args: []string{"--sizes", "16", "--apple-touch-sizes", "152"}, want: `<link rel="apple-touch-icon" sizes="152x152" href="/apple-touch-icon-152x152.png">`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("favicongen", flag.ContinueOnError)
+			f := defineFlags(fs)
+			if err := fs.Parse(append([]string{"--generate-html-tags"}, tt.args...)); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			config, err := parseConfig(fs, f)
+			if err != nil {
+				t.Fatalf("parseConfig() error = %v", err)
+			}
+
+			got := generator.GenerateHTMLTags(config.buildHTMLTagsConfig())
+			if tt.wantMissing && strings.Contains(got, "apple-touch-icon") {
+				t.Errorf("unexpected apple-touch-icon tag:\n%s", got)
+			}
+			if tt.want != "" && !strings.Contains(got, tt.want) {
+				t.Errorf("missing %s:\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBuildHTMLTagsConfigICO(t *testing.T) {
+	config := &Config{GenerateICO: true, ICOSizes: []int{16, 32}}
+	if !config.buildHTMLTagsConfig().IncludeICO {
+		t.Error("IncludeICO should be true when favicon.ico is generated")
+	}
+
+	config.GenerateICO = false
+	if config.buildHTMLTagsConfig().IncludeICO {
+		t.Error("IncludeICO should be false with --ico=false")
+	}
+
+	got := generator.GenerateHTMLTags(config.buildHTMLTagsConfig())
+	if strings.Contains(got, "favicon.ico") {
+		t.Errorf("HTML links favicon.ico although none is generated:\n%s", got)
+	}
+}
+
+func TestDefineFlags(t *testing.T) {
+	// Note: This test just verifies the function doesn't panic
+	// and returns a non-nil result
+	f := defineFlags(flag.NewFlagSet("test", flag.ContinueOnError))
+	if f == nil {
+		t.Error("defineFlags() returned nil")
+	}
+	if f.source == nil {
+		t.Error("source flag is nil")
+	}
+	if f.output == nil {
+		t.Error("output flag is nil")
+	}
+}
+
+func TestBuildConfig(t *testing.T) {
+	source := "test.png"
+	output := "./output"
+	f := &flags{
+		source:              &source,
+		output:              &output,
+		nameTemplate:        strPtr("icons/{name}-{w}x{h}.{ext}"),
+		backend:             new(string),
+		jobs:                new(int),
+		upscale:             boolPtr(true),
+		fit:                 strPtr("cover"),
+		gravity:             strPtr("north"),
+		padding:             strPtr("0,180=12%"),
+		background:          strPtr("transparent"),
+		timeout:             new(time.Duration),
+		generateHTML:        boolPtr(true),
+		generateManifest:    boolPtr(false),
+		generateICO:         boolPtr(true),
+		icoEncoding:         new(string),
+		appleTouchIcon:      boolPtr(true),
+		appleTouchPadding:   strPtr("10%"),
+		appleTouchRoot:      boolPtr(true),
+		android:             boolPtr(true),
+		androidSizesStr:     strPtr("192,512"),
+		maskable:            boolPtr(true),
+		monochrome:          boolPtr(true),
+		monochromeThreshold: strPtr("0.25"),
+		maskIcon:            boolPtr(true),
+		maskIconColor:       strPtr("#5bbad5"),
+		svgFavicon:          boolPtr(true),
+		svgDarkFill:         strPtr("#ffffff"),
+		svgDarkColors:       new(string),
+		windows:             boolPtr(true),
+		tileColor:           new(string),
+		splash:              boolPtr(true),
+		generateHTMLOnly:    boolPtr(false),
+		appName:             new(string),
+		appShortName:        new(string),
+		appDescription:      new(string),
+		appStartURL:         strPtr("/"),
+		appDisplay:          strPtr("standalone"),
+		appOrientation:      strPtr("any"),
+		appScope:            strPtr("/"),
+		appThemeColor:       strPtr("#ffffff"),
+		appBackgroundColor:  strPtr("#ffffff"),
+		appIcon:             new(string),
+	}
+
+	targets := generator.SizeTargets(processor.Squares(16, 32)...)
+	icoSizes := []int{16, 24, 32}
+	categories := []string{"test"}
+
+	appleTouchSizes := []int{152, 180}
+	androidSizes := []int{192, 512}
+	maskableSizes := []int{192, 512}
+	monochromeSizes := []int{192}
+	config := buildConfig(f, targets, icoSizes, appleTouchSizes, androidSizes, maskableSizes, monochromeSizes, categories)
+
+	if config.Source != source {
+		t.Errorf("Source = %q, want %q", config.Source, source)
+	}
+	if config.Output != output {
+		t.Errorf("Output = %q, want %q", config.Output, output)
+	}
+	if len(config.Targets) != len(targets) {
+		t.Errorf("Targets = %v, want %v", config.Targets, targets)
+	}
+	if config.names() != "icons/{name}-{w}x{h}.{ext}" {
+		t.Errorf("names() = %q, want icons/{name}-{w}x{h}.{ext}", config.names())
+	}
+	if !config.Upscale {
+		t.Error("Upscale should be copied from flags")
+	}
+	if config.Fit != "cover" || config.Gravity != "north" {
+		t.Errorf("Fit, Gravity = %q, %q, want cover, north", config.Fit, config.Gravity)
+	}
+	if len(config.ICOSizes) != len(icoSizes) {
+		t.Errorf("ICOSizes = %v, want %v", config.ICOSizes, icoSizes)
+	}
+	if !config.AppleTouchIcon || !slices.Equal(config.AppleTouchSizes, appleTouchSizes) || config.AppleTouchPadding != "10%" || !config.AppleTouchRoot {
+		t.Errorf("apple-touch-icon config = %v, %v, %q, %v", config.AppleTouchIcon, config.AppleTouchSizes, config.AppleTouchPadding, config.AppleTouchRoot)
+	}
+	if !config.Maskable || !slices.Equal(config.MaskableSizes, maskableSizes) {
+		t.Errorf("maskable config = %v, %v", config.Maskable, config.MaskableSizes)
+	}
+	if !config.Monochrome || !slices.Equal(config.MonochromeSizes, monochromeSizes) || config.MonochromeThreshold != "0.25" {
+		t.Errorf("monochrome config = %v, %v, %q", config.Monochrome, config.MonochromeSizes, config.MonochromeThreshold)
+	}
+	if !config.SVGFavicon || config.SVGDarkFill != "#ffffff" {
+		t.Errorf("SVG config = %v, %q", config.SVGFavicon, config.SVGDarkFill)
+	}
+	if config.svgFavicon() {
+		t.Error("svgFavicon() should be false for a PNG source")
+	}
+	if !config.Splash {
+		t.Error("Splash should be true")
+	}
+	if config.tileColor() != "#ffffff" {
+		t.Errorf("tileColor() = %q, want theme color #ffffff", config.tileColor())
+	}
+	if config.maskIconColor() != "#5bbad5" {
+		t.Errorf("maskIconColor() = %q, want #5bbad5", config.maskIconColor())
+	}
+	if len(config.AppCategories) != len(categories) {
+		t.Errorf("AppCategories = %v, want %v", config.AppCategories, categories)
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestRunRejectsInvalidOptions(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "favicongen-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	source := filepath.Join(tmpDir, "logo.png")
+	if err := os.WriteFile(source, []byte("test"), 0644); err != nil {
+		t.Fatalf("failed to create source: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		config *Config
+	}{
+		{name: "negative timeout", config: &Config{Source: source, Timeout: -time.Second}},
+		{name: "negative jobs", config: &Config{Source: source, Jobs: -1}},
+		{name: "unknown ICO encoding", config: &Config{Source: source, ICOEncoding: "gif"}},
+		{name: "unknown fit", config: &Config{Source: source, Fit: "stretch"}},
+		{name: "unknown gravity", config: &Config{Source: source, Gravity: "top"}},
+		{name: "invalid padding", config: &Config{Source: source, Padding: "180=wide"}},
+		{name: "invalid background", config: &Config{Source: source, Background: "0=#fff"}},
+		{name: "invalid apple-touch-icon padding", config: &Config{Source: source, AppleTouchIcon: true, AppleTouchPadding: "60%"}},
+		{name: "invalid monochrome threshold", config: &Config{Source: source, Monochrome: true, MonochromeThreshold: "2"}},
+		{name: "mask icon from raster source", config: &Config{Source: source, MaskIcon: true}},
+		{name: "invalid SVG dark colors", config: &Config{Source: source, SVGDarkColors: "#000"}},
+		{name: "named app background color", config: &Config{Source: source, AppleTouchIcon: true, AppBackgroundColor: "white"}},
+		{name: "invalid name template", config: &Config{Source: source, NameTemplate: "../{name}-{w}x{h}.{ext}"}},
+		{name: "target named like an apple-touch-icon of another size", config: &Config{Source: source, Targets: []generator.Target{{Size: processor.Square(192), Name: "apple-touch-icon.png"}}, AppleTouchIcon: true, AppleTouchSizes: []int{180}, AppleTouchRoot: true}},
+		{name: "target named like an android-chrome icon of another size", config: &Config{Source: source, Targets: []generator.Target{{Size: processor.Square(180), Name: "android-chrome-192x192.png"}}, Android: true, AndroidSizes: []int{192}, GenerateManifest: true}},
+		{name: "name template shared by favicons and apple-touch-icons", config: &Config{Source: source, NameTemplate: "icons/{w}.png", Targets: generator.SizeTargets(processor.Squares(180)...), AppleTouchIcon: true, AppleTouchSizes: []int{180}}},
+		{name: "invalid name template in HTML-only mode", config: &Config{GenerateHTMLOnly: true, GenerateManifest: true, NameTemplate: "../{name}.{ext}"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.Output = filepath.Join(tmpDir, "out")
+			if err := run(context.Background(), tt.config); err == nil {
+				t.Error("expected error from run()")
+			}
+			if _, err := os.Stat(tt.config.Output); err == nil {
+				t.Error("output directory created despite invalid options")
+			}
+		})
+	}
+}
+
+func TestRunNamedTargetsReplaceSetEntries(t *testing.T) {
+	tmpDir := t.TempDir()
+	source := filepath.Join(tmpDir, "logo.png")
+	f, err := os.Create(source)
+	if err != nil {
+		t.Fatalf("failed to create source: %v", err)
+	}
+	if err := png.Encode(f, image.NewNRGBA(image.Rect(0, 0, 512, 512))); err != nil {
+		t.Fatalf("failed to encode source: %v", err)
+	}
+	f.Close()
+
+	tests := []struct {
+		name  string
+		args  []string
+		files []string
+	}{
+		{
+			name:  "default flags",
+			files: []string{"favicon-16x16.png", "favicon-32x32.png", "apple-touch-icon.png", "android-chrome-192x192.png"},
+		},
+		{
+			name:  "with manifest",
+			args:  []string{"--manifest"},
+			files: []string{"apple-touch-icon.png", "android-chrome-192x192.png", "android-chrome-512x512.png", "manifest.webmanifest"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := filepath.Join(tmpDir, strings.ReplaceAll(tt.name, " ", "-"))
+			args := append([]string{"--source", source, "--output", output, "--sizes", "16,32,180:apple-touch-icon.png,192:android-chrome"}, tt.args...)
+
+			fs := flag.NewFlagSet("favicongen", flag.ContinueOnError)
+			fl := defineFlags(fs)
+			if err := fs.Parse(args); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			config, err := parseConfig(fs, fl)
+			if err != nil {
+				t.Fatalf("parseConfig() error = %v", err)
+			}
+
+			if err := run(context.Background(), config); err != nil {
+				t.Fatalf("run() error = %v", err)
+			}
+			for _, name := range tt.files {
+				if _, err := os.Stat(filepath.Join(output, name)); err != nil {
+					t.Errorf("expected %s: %v", name, err)
+				}
+			}
+		})
+	}
+}
+
+func TestRunWidthOnlyNameTemplate(t *testing.T) {
+	tmpDir := t.TempDir()
+	source := filepath.Join(tmpDir, "logo.png")
+	f, err := os.Create(source)
+	if err != nil {
+		t.Fatalf("failed to create source: %v", err)
+	}
+	if err := png.Encode(f, image.NewNRGBA(image.Rect(0, 0, 64, 64))); err != nil {
+		t.Fatalf("failed to encode source: %v", err)
+	}
+	f.Close()
+
+	for _, template := range []string{"icon-{w}.png", "icons/{w}.png"} {
+		t.Run(template, func(t *testing.T) {
+			config := &Config{
+				Source:       source,
+				Output:       filepath.Join(tmpDir, strings.NewReplacer("/", "-", "{", "", "}", "").Replace(template)),
+				Targets:      generator.SizeTargets(processor.Squares(16, 32)...),
+				NameTemplate: template,
+				GenerateHTML: true,
+			}
+			if err := run(context.Background(), config); err != nil {
+				t.Fatalf("run() error = %v", err)
+			}
+
+			html, err := os.ReadFile(filepath.Join(config.Output, "favicon-tags.html"))
+			if err != nil {
+				t.Fatalf("HTML tags not written: %v", err)
+			}
+			for _, size := range []string{"16", "32"} {
+				name := strings.ReplaceAll(template, "{w}", size)
+				if _, err := os.Stat(filepath.Join(config.Output, filepath.FromSlash(name))); err != nil {
+					t.Errorf("expected %s: %v", name, err)
+				}
+				if !strings.Contains(string(html), `href="/`+name+`"`) {
+					t.Errorf("HTML tags do not link %s:\n%s", name, html)
+				}
+			}
+		})
+	}
+}
+
+// svgTestProcessor accepts SVG sources, writes placeholder PNGs and fails to
+// create ICO files
+type svgTestProcessor struct{}
+
+func (svgTestProcessor) Name() string      { return "svgtest" }
+func (svgTestProcessor) IsAvailable() bool { return true }
+func (svgTestProcessor) Capabilities() processor.Capabilities {
+	return processor.Capabilities{InputFormats: []string{"svg"}, OutputFormats: []string{"png", "ico"}, ICO: true, Upscale: true}
+}
+func (svgTestProcessor) Resize(_ context.Context, _, outputPath string, _ processor.Size, _ processor.ResizeOptions) error {
+	return os.WriteFile(outputPath, nil, 0644)
+}
+func (svgTestProcessor) ConvertToICO(context.Context, []string, string) error {
+	return errors.New("ICO conversion failed")
+}
+
+func TestRunSVGFaviconFailureWarns(t *testing.T) {
+	processor.Register("svgtest", func() processor.Processor { return svgTestProcessor{} }, 0)
+
+	tmpDir := t.TempDir()
+	source := filepath.Join(tmpDir, "logo.svg")
+	svg := `<?xml version="1.0" encoding="Shift_JIS"?><svg xmlns="http://www.w3.org/2000/svg"/>`
+	if err := os.WriteFile(source, []byte(svg), 0644); err != nil {
+		t.Fatalf("failed to write source: %v", err)
+	}
+
+	config := &Config{
+		Source:       source,
+		Output:       filepath.Join(tmpDir, "out"),
+		Targets:      generator.SizeTargets(processor.Squares(16, 32)...),
+		Backend:      "svgtest",
+		SVGFavicon:   true,
+		GenerateHTML: true,
+	}
+	if err := run(context.Background(), config); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(config.Output, "favicon.svg")); err == nil {
+		t.Error("favicon.svg written despite conversion failure")
+	}
+	html, err := os.ReadFile(filepath.Join(config.Output, "favicon-tags.html"))
+	if err != nil {
+		t.Fatalf("HTML tags not written: %v", err)
+	}
+	if strings.Contains(string(html), "favicon.svg") {
+		t.Errorf("HTML tags link the missing favicon.svg:\n%s", html)
+	}
+}
+
+func TestRunICOFailureWarns(t *testing.T) {
+	processor.Register("svgtest", func() processor.Processor { return svgTestProcessor{} }, 0)
+
+	tmpDir := t.TempDir()
+	source := filepath.Join(tmpDir, "logo.svg")
+	if err := os.WriteFile(source, []byte(`<svg xmlns="http://www.w3.org/2000/svg"/>`), 0644); err != nil {
+		t.Fatalf("failed to write source: %v", err)
+	}
+
+	config := &Config{
+		Source:       source,
+		Output:       filepath.Join(tmpDir, "out"),
+		Targets:      generator.SizeTargets(processor.Squares(16, 32)...),
+		Backend:      "svgtest",
+		GenerateICO:  true,
+		ICOSizes:     []int{16, 32},
+		GenerateHTML: true,
+	}
+	if err := run(context.Background(), config); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+
+	html, err := os.ReadFile(filepath.Join(config.Output, "favicon-tags.html"))
+	if err != nil {
+		t.Fatalf("HTML tags not written: %v", err)
+	}
+	if strings.Contains(string(html), "favicon.ico") {
+		t.Errorf("HTML tags link the missing favicon.ico:\n%s", html)
+	}
+}
+
+func TestParseResizeOptions(t *testing.T) {
+	config := &Config{
+		Upscale:    true,
+		Fit:        "cover",
+		Padding:    "2px,180=12%,310x150=10%",
+		Background: "transparent,180=#ffffff,192=#000",
+	}
+
+	opts, perSize, err := parseResizeOptions(config)
+	if err != nil {
+		t.Fatalf("parseResizeOptions() error = %v", err)
+	}
+
+	want := processor.ResizeOptions{Fit: processor.FitCover, Upscale: true, Padding: processor.Padding{Value: 2}}
+	if opts != want {
+		t.Errorf("default options = %+v, want %+v", opts, want)
+	}
+
+	apple := want
+	apple.Padding = processor.Padding{Value: 12, Percent: true}
+	apple.Background = color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+	if perSize[processor.Square(180)] != apple {
+		t.Errorf("180 options = %+v, want %+v", perSize[processor.Square(180)], apple)
+	}
+
+	android := want
+	android.Background = color.NRGBA{A: 255}
+	if perSize[processor.Square(192)] != android {
+		t.Errorf("192 options = %+v, want %+v", perSize[processor.Square(192)], android)
+	}
+
+	tile := want
+	tile.Padding = processor.Padding{Value: 10, Percent: true}
+	if got := perSize[processor.Size{Width: 310, Height: 150}]; got != tile {
+		t.Errorf("310x150 options = %+v, want %+v", got, tile)
+	}
+
+	if len(perSize) != 3 {
+		t.Errorf("got overrides for %d sizes, want 3", len(perSize))
+	}
+}
+
+func TestParseAppleTouchOptions(t *testing.T) {
+	base := processor.ResizeOptions{Fit: processor.FitCover, Upscale: true, Background: color.Black}
+
+	t.Run("flattens onto app background", func(t *testing.T) {
+		config := &Config{AppleTouchIcon: true, AppleTouchPadding: "12%", AppBackgroundColor: "#336699"}
+		opts, err := parseAppleTouchOptions(config, base)
+		if err != nil {
+			t.Fatalf("parseAppleTouchOptions() error = %v", err)
+		}
+		want := processor.ResizeOptions{
+			Fit:        processor.FitCover,
+			Upscale:    true,
+			Padding:    processor.Padding{Value: 12, Percent: true},
+			Background: color.NRGBA{R: 0x33, G: 0x66, B: 0x99, A: 0xff},
+		}
+		if opts != want {
+			t.Errorf("options = %+v, want %+v", opts, want)
+		}
+	})
+
+	t.Run("disabled ignores app background", func(t *testing.T) {
+		config := &Config{AppBackgroundColor: "white"}
+		if _, err := parseAppleTouchOptions(config, base); err != nil {
+			t.Errorf("parseAppleTouchOptions() error = %v", err)
+		}
+	})
+}
+
+func TestParseMaskableOptions(t *testing.T) {
+	base := processor.ResizeOptions{Gravity: processor.GravityNorth, Upscale: true}
+
+	t.Run("pads into safe zone over app background", func(t *testing.T) {
+		config := &Config{GenerateManifest: true, Maskable: true, MaskableSizes: []int{192}, AppBackgroundColor: "#000"}
+		opts, err := parseMaskableOptions(config, base)
+		if err != nil {
+			t.Fatalf("parseMaskableOptions() error = %v", err)
+		}
+		want := processor.ResizeOptions{
+			Gravity:    processor.GravityNorth,
+			Upscale:    true,
+			Padding:    generator.MaskablePadding(),
+			Background: color.NRGBA{A: 0xff},
+		}
+		if opts != want {
+			t.Errorf("options = %+v, want %+v", opts, want)
+		}
+	})
+
+	t.Run("requires manifest", func(t *testing.T) {
+		config := &Config{Maskable: true, MaskableSizes: []int{192}}
+		if sizes := config.maskableSizes(); sizes != nil {
+			t.Errorf("maskableSizes() = %v without manifest, want nil", sizes)
+		}
+	})
+}
+
+func TestParseMonochromeOptions(t *testing.T) {
+	base := processor.ResizeOptions{Upscale: true, Background: color.White}
+	opts, err := parseMonochromeOptions(&Config{MonochromeThreshold: "0.4"}, base)
+	if err != nil {
+		t.Fatalf("parseMonochromeOptions() error = %v", err)
+	}
+
+	want := processor.ResizeOptions{Upscale: true, Monochrome: true, Threshold: 0.4}
+	if opts != want {
+		t.Errorf("options = %+v, want %+v", opts, want)
+	}
+}
+
+func TestParseSplashOptions(t *testing.T) {
+	base := processor.ResizeOptions{Upscale: true, Fit: processor.FitCover, Gravity: processor.GravityNorth}
+	opts, err := parseSplashOptions(&Config{Splash: true, AppBackgroundColor: "#102030"}, base)
+	if err != nil {
+		t.Fatalf("parseSplashOptions() error = %v", err)
+	}
+
+	want := processor.ResizeOptions{
+		Upscale:    true,
+		Fit:        processor.FitContain,
+		Gravity:    processor.GravityCenter,
+		Padding:    generator.SplashPadding,
+		Background: color.NRGBA{R: 0x10, G: 0x20, B: 0x30, A: 0xff},
+	}
+	if opts != want {
+		t.Errorf("options = %+v, want %+v", opts, want)
+	}
+
+	if _, err := parseSplashOptions(&Config{Splash: true, AppBackgroundColor: "navy"}, base); err == nil {
+		t.Error("expected error for named app background color")
+	}
+}
+
+func TestParseSVGDarkColors(t *testing.T) {
+	config := &Config{SVGDarkFill: "white", SVGDarkColors: "#000=#fff, #123456 = teal"}
+	dark, err := parseSVGDarkColors(config)
+	if err != nil {
+		t.Fatalf("parseSVGDarkColors() error = %v", err)
+	}
+
+	want := []generator.ColorReplacement{{From: "#000", To: "#fff"}, {From: "#123456", To: "teal"}}
+	if dark.Fill != "white" || !slices.Equal(dark.Replace, want) {
+		t.Errorf("parseSVGDarkColors() = %+v", dark)
+	}
+
+	if _, err := parseSVGDarkColors(&Config{SVGDarkFill: "red}"}); err == nil {
+		t.Error("expected error for unsafe fill")
+	}
+}
+
+func TestBuildRequirements(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "favicongen-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	smallPNG := filepath.Join(tmpDir, "small.png")
+	f, err := os.Create(smallPNG)
+	if err != nil {
+		t.Fatalf("failed to create PNG: %v", err)
+	}
+	if err := png.Encode(f, image.NewNRGBA(image.Rect(0, 0, 64, 64))); err != nil {
+		t.Fatalf("failed to encode PNG: %v", err)
+	}
+	f.Close()
+
+	tests := []struct {
+		name        string
+		config      *Config
+		wantFormat  string
+		wantICO     bool
+		wantUpscale bool
+	}{
+		{
+			name:       "vector source never needs upscaling",
+			config:     &Config{Source: "logo.svg", Targets: generator.SizeTargets(processor.Squares(512)...), GenerateICO: true, ICOSizes: []int{16}},
+			wantFormat: "svg",
+			wantICO:    true,
+		},
+		{
+			name:        "small raster source needs upscaling",
+			config:      &Config{Source: smallPNG, Targets: generator.SizeTargets(processor.Squares(16, 128)...), Upscale: true},
+			wantFormat:  "png",
+			wantUpscale: true,
+		},
+		{
+			name:       "upscaling denied",
+			config:     &Config{Source: smallPNG, Targets: generator.SizeTargets(processor.Squares(16, 128)...), Upscale: false},
+			wantFormat: "png",
+		},
+		{
+			name:       "raster source large enough",
+			config:     &Config{Source: smallPNG, Targets: generator.SizeTargets(processor.Squares(16, 64)...)},
+			wantFormat: "png",
+		},
+		{
+			name:        "rectangular sizes count their longer side",
+			config:      &Config{Source: smallPNG, Targets: generator.SizeTargets(processor.Size{Width: 128, Height: 32}), Upscale: true},
+			wantFormat:  "png",
+			wantUpscale: true,
+		},
+		{
+			name:        "ICO sizes count towards upscaling",
+			config:      &Config{Source: smallPNG, Targets: generator.SizeTargets(processor.Squares(16)...), GenerateICO: true, ICOSizes: []int{256}, Upscale: true},
+			wantFormat:  "png",
+			wantICO:     true,
+			wantUpscale: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := buildRequirements(tt.config)
+			if req.InputFormat != tt.wantFormat {
+				t.Errorf("InputFormat = %q, want %q", req.InputFormat, tt.wantFormat)
+			}
+			if req.ICO != tt.wantICO {
+				t.Errorf("ICO = %v, want %v", req.ICO, tt.wantICO)
+			}
+			if req.Upscale != tt.wantUpscale {
+				t.Errorf("Upscale = %v, want %v", req.Upscale, tt.wantUpscale)
+			}
+		})
+	}
+}
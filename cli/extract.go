@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/fathurrohman26/favicongen/internal/generator"
+)
+
+// runExtract implements the extract subcommand
+func runExtract(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("extract", flag.ContinueOnError)
+	output := fs.String("output", "./favicons", "Output directory for extracted PNG files")
+	nameTemplate := fs.String("name-template", string(generator.DefaultNameTemplate), "File name template for extracted images ({name}, {w}, {h}, {size}, {ext})")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		return fmt.Errorf("usage: favicongen extract [--output <dir>] [--name-template <template>] <file.ico> [dir]")
+	}
+	if fs.NArg() == 2 {
+		*output = fs.Arg(1)
+	}
+
+	names, err := generator.ParseNameTemplate(*nameTemplate)
+	if err != nil {
+		return err
+	}
+
+	paths, err := generator.ExtractICO(fs.Arg(0), *output, names)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		fmt.Fprintf(w, "✓ Extracted %s\n", path)
+	}
+	fmt.Fprintf(w, "\n✓ Extracted %d image(s) from %s\n", len(paths), fs.Arg(0))
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fathurrohman26/favicongen/processor"
+)
+
+func TestRunExtract(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "favicongen-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	icoPath := writeTestICO(t, tmpDir, []int{16, 32}, processor.ICOEncodingBMP)
+	outputDir := filepath.Join(tmpDir, "extracted")
+
+	var out bytes.Buffer
+	if err := runExtract([]string{icoPath, outputDir}, &out); err != nil {
+		t.Fatalf("runExtract() error = %v", err)
+	}
+
+	for _, name := range []string{"favicon-16x16.png", "favicon-32x32.png"} {
+		if _, err := os.Stat(filepath.Join(outputDir, name)); err != nil {
+			t.Errorf("expected %s to be extracted", name)
+		}
+	}
+
+	if !strings.Contains(out.String(), "Extracted 2 image(s)") {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+
+	if err := runExtract([]string{"--name-template", "icons/{w}.png", icoPath, outputDir}, &bytes.Buffer{}); err != nil {
+		t.Fatalf("runExtract() with name template error = %v", err)
+	}
+	for _, name := range []string{"16.png", "32.png"} {
+		if _, err := os.Stat(filepath.Join(outputDir, "icons", name)); err != nil {
+			t.Errorf("expected icons/%s to be extracted", name)
+		}
+	}
+}
+
+func TestRunExtractErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "missing path", args: nil},
+		{name: "too many arguments", args: []string{"a.ico", "out", "extra"}},
+		{name: "non-existent file", args: []string{"/nonexistent/favicon.ico"}},
+		{name: "invalid name template", args: []string{"--name-template", "../{name}.{ext}", "favicon.ico"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := runExtract(tt.args, &bytes.Buffer{}); err == nil {
+				t.Error("expected error from runExtract()")
+			}
+		})
+	}
+}
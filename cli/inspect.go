@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/fathurrohman26/favicongen/processor"
+)
+
+// ICOReport describes the contents of an ICO file
+type ICOReport struct {
+	Path   string           `json:"path"`
+	Images []ICOImageReport `json:"images"`
+}
+
+// ICOImageReport describes a single ICO directory entry
+type ICOImageReport struct {
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+	BitCount int    `json:"bit_count"`
+	Format   string `json:"format"`
+	Bytes    int    `json:"bytes"`
+}
+
+// runInspect implements the inspect subcommand
+func runInspect(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("inspect", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "Print the report as JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: favicongen inspect [--json] <file.ico>")
+	}
+
+	report, err := inspectICO(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return writeInspectJSON(w, report)
+	}
+	return writeInspectTable(w, report)
+}
+
+// inspectICO reads an ICO file and builds a report of its entries
+func inspectICO(path string) (*ICOReport, error) {
+	entries, err := processor.ReadICO(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+
+	report := &ICOReport{
+		Path:   path,
+		Images: make([]ICOImageReport, 0, len(entries)),
+	}
+	for _, e := range entries {
+		report.Images = append(report.Images, ICOImageReport{
+			Width:    e.Width,
+			Height:   e.Height,
+			BitCount: e.BitCount,
+			Format:   string(e.Format),
+			Bytes:    len(e.Data),
+		})
+	}
+
+	return report, nil
+}
+
+func writeInspectJSON(w io.Writer, report *ICOReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+func writeInspectTable(w io.Writer, report *ICOReport) error {
+	fmt.Fprintf(w, "%s: %d image(s)\n\n", report.Path, len(report.Images))
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "#\tSIZE\tBPP\tFORMAT\tBYTES")
+	for i, img := range report.Images {
+		fmt.Fprintf(tw, "%d\t%dx%d\t%d\t%s\t%d\n", i, img.Width, img.Height, img.BitCount, img.Format, img.Bytes)
+	}
+	return tw.Flush()
+}
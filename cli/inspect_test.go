@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fathurrohman26/favicongen/processor"
+)
+
+// writeTestICO creates an ICO file with one entry per size in dir
+func writeTestICO(t *testing.T, dir string, sizes []int, encoding processor.ICOEncoding) string {
+	t.Helper()
+
+	var pngPaths []string
+	for _, size := range sizes {
+		path := filepath.Join(dir, fmt.Sprintf("favicon-%dx%d.png", size, size))
+		f, err := os.Create(path)
+		if err != nil {
+			t.Fatalf("failed to create PNG: %v", err)
+		}
+		if err := png.Encode(f, image.NewNRGBA(image.Rect(0, 0, size, size))); err != nil {
+			t.Fatalf("failed to encode PNG: %v", err)
+		}
+		f.Close()
+		pngPaths = append(pngPaths, path)
+	}
+
+	icoPath := filepath.Join(dir, "favicon.ico")
+	if err := processor.WriteICO(pngPaths, icoPath, encoding); err != nil {
+		t.Fatalf("failed to write ICO: %v", err)
+	}
+	return icoPath
+}
+
+func TestRunInspectTable(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "favicongen-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	icoPath := writeTestICO(t, tmpDir, []int{16, 256}, processor.ICOEncodingLegacy)
+
+	var out bytes.Buffer
+	if err := runInspect([]string{icoPath}, &out); err != nil {
+		t.Fatalf("runInspect() error = %v", err)
+	}
+
+	got := out.String()
+	for _, want := range []string{"2 image(s)", "16x16", "256x256", "bmp", "png"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("inspect output missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestRunInspectJSON(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "favicongen-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	icoPath := writeTestICO(t, tmpDir, []int{32}, processor.ICOEncodingBMP)
+
+	var out bytes.Buffer
+	if err := runInspect([]string{"--json", icoPath}, &out); err != nil {
+		t.Fatalf("runInspect() error = %v", err)
+	}
+
+	var report ICOReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("failed to parse JSON output: %v", err)
+	}
+
+	if len(report.Images) != 1 {
+		t.Fatalf("got %d images, want 1", len(report.Images))
+	}
+	img := report.Images[0]
+	if img.Width != 32 || img.Height != 32 || img.BitCount != 32 || img.Format != "bmp" {
+		t.Errorf("image = %+v, want 32x32 32bpp bmp", img)
+	}
+	if img.Bytes != 40+32*32*4+32*4 {
+		t.Errorf("image bytes = %d, want %d", img.Bytes, 40+32*32*4+32*4)
+	}
+}
+
+func TestRunInspectErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "missing path", args: nil},
+		{name: "non-existent file", args: []string{"/nonexistent/favicon.ico"}},
+		{name: "not an ICO file", args: []string{"main.go"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := runInspect(tt.args, &bytes.Buffer{}); err == nil {
+				t.Error("expected error from runInspect()")
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// preset is a named set of flag values. Every preset sets all output
+// switches, so the result does not depend on the flag defaults.
+type preset struct {
+	description string
+	flags       map[string]string
+}
+
+// presets maps --preset names to the flags they expand to
+var presets = map[string]preset{
+	"minimal": {
+		description: "favicon.ico, 16/32 PNGs and the 180px apple-touch-icon",
+		flags: map[string]string{
+			"sizes":             "16,32",
+			"ico":               "true",
+			"svg":               "true",
+			"apple-touch-icon":  "true",
+			"apple-touch-sizes": "180",
+			"manifest":          "false",
+			"android":           "false",
+			"maskable":          "false",
+			"monochrome":        "false",
+			"windows":           "false",
+			"splash":            "false",
+		},
+	},
+	"web": {
+		description: "desktop browser favicons from 16 to 512px",
+		flags: map[string]string{
+			"sizes":             "16,32,48,64,128,180,256,512",
+			"ico":               "true",
+			"svg":               "true",
+			"apple-touch-icon":  "true",
+			"apple-touch-sizes": "180",
+			"manifest":          "false",
+			"android":           "false",
+			"maskable":          "false",
+			"monochrome":        "false",
+			"windows":           "false",
+			"splash":            "false",
+		},
+	},
+	"pwa": {
+		description: "installable web app: manifest with android-chrome, maskable and monochrome icons plus iOS splash screens",
+		flags: map[string]string{
+			"sizes":             "16,32,48",
+			"ico":               "true",
+			"svg":               "true",
+			"apple-touch-icon":  "true",
+			"apple-touch-sizes": "180",
+			"manifest":          "true",
+			"android":           "true",
+			"android-sizes":     "192,512",
+			"maskable":          "true",
+			"maskable-sizes":    "192,512",
+			"monochrome":        "true",
+			"monochrome-sizes":  "192,512",
+			"windows":           "false",
+			"splash":            "true",
+		},
+	},
+	"apple": {
+		description: "apple-touch-icons for every iPhone and iPad plus iOS splash screens",
+		flags: map[string]string{
+			"sizes":             "16,32",
+			"ico":               "false",
+			"svg":               "false",
+			"apple-touch-icon":  "true",
+			"apple-touch-sizes": "120,152,167,180",
+			"manifest":          "false",
+			"android":           "false",
+			"maskable":          "false",
+			"monochrome":        "false",
+			"windows":           "false",
+			"splash":            "true",
+		},
+	},
+	"android": {
+		description: "manifest with android-chrome launcher icons at every density and maskable icons",
+		flags: map[string]string{
+			"sizes":            "16,32",
+			"ico":              "false",
+			"svg":              "false",
+			"apple-touch-icon": "false",
+			"manifest":         "true",
+			"android":          "true",
+			"android-sizes":    "36,48,72,96,144,192,512",
+			"maskable":         "true",
+			"maskable-sizes":   "192,512",
+			"monochrome":       "false",
+			"windows":          "false",
+			"splash":           "false",
+		},
+	},
+	"windows": {
+		description: "favicon.ico, Windows tiles and browserconfig.xml",
+		flags: map[string]string{
+			"sizes":            "16,32",
+			"ico":              "true",
+			"ico-sizes":        "16,24,32,48,64,256",
+			"svg":              "false",
+			"apple-touch-icon": "false",
+			"manifest":         "false",
+			"android":          "false",
+			"maskable":         "false",
+			"monochrome":       "false",
+			"windows":          "true",
+			"splash":           "false",
+		},
+	},
+	"all": {
+		description: "every target: web, pwa, apple, android and windows combined",
+		flags: map[string]string{
+			"sizes":             "16,32,48,64,128,180,256,512",
+			"ico":               "true",
+			"ico-sizes":         "16,24,32,48,64,256",
+			"svg":               "true",
+			"apple-touch-icon":  "true",
+			"apple-touch-sizes": "120,152,167,180",
+			"manifest":          "true",
+			"android":           "true",
+			"android-sizes":     "36,48,72,96,144,192,512",
+			"maskable":          "true",
+			"maskable-sizes":    "192,512",
+			"monochrome":        "true",
+			"monochrome-sizes":  "192,512",
+			"windows":           "true",
+			"splash":            "true",
+		},
+	},
+}
+
+// presetNames returns the preset names in alphabetical order
+func presetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// applyPreset sets the flags of the named preset on fs. Flags given on the
+// command line win over the preset; an empty name is a no-op.
+func applyPreset(fs *flag.FlagSet, name string) error {
+	if name == "" {
+		return nil
+	}
+
+	p, ok := presets[name]
+	if !ok {
+		return fmt.Errorf("unknown preset %q (expected %s)", name, strings.Join(presetNames(), ", "))
+	}
+
+	explicit := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+	for flagName, value := range p.flags {
+		if explicit[flagName] {
+			continue
+		}
+		if err := fs.Set(flagName, value); err != nil {
+			return fmt.Errorf("preset %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
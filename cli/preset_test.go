@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"flag"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// presetOutputs lists the output switches every preset must set
+var presetOutputs = []string{"ico", "svg", "apple-touch-icon", "manifest", "android", "maskable", "monochrome", "windows", "splash"}
+
+func TestPresetsSetKnownFlags(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	defineFlags(fs)
+
+	for name, p := range presets {
+		for flagName, value := range p.flags {
+			if fs.Lookup(flagName) == nil {
+				t.Errorf("preset %s sets unknown flag --%s", name, flagName)
+				continue
+			}
+
+			var err error
+			switch {
+			case flagName == "sizes":
+				_, err = parseTargets(value)
+			case strings.HasSuffix(flagName, "-sizes"):
+				_, err = parseSizes(value)
+			default:
+				_, err = strconv.ParseBool(value)
+			}
+			if err != nil {
+				t.Errorf("preset %s: invalid --%s: %v", name, flagName, err)
+			}
+		}
+		for _, output := range presetOutputs {
+			if _, ok := p.flags[output]; !ok {
+				t.Errorf("preset %s does not set --%s", name, output)
+			}
+		}
+	}
+}
+
+func TestApplyPreset(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	sizes := fs.String("sizes", "16,32,48,64,128,180,256,512", "")
+	manifest := fs.Bool("manifest", false, "")
+	windows := fs.Bool("windows", false, "")
+	for _, name := range []string{"ico", "svg", "apple-touch-icon", "android", "maskable", "monochrome", "splash"} {
+		fs.Bool(name, false, "")
+	}
+	for _, name := range []string{"apple-touch-sizes", "android-sizes", "maskable-sizes", "monochrome-sizes"} {
+		fs.String(name, "", "")
+	}
+
+	if err := fs.Parse([]string{"--sizes", "64"}); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if err := applyPreset(fs, "pwa"); err != nil {
+		t.Fatalf("applyPreset() error = %v", err)
+	}
+
+	if *sizes != "64" {
+		t.Errorf("sizes = %q, want explicit value 64", *sizes)
+	}
+	if !*manifest {
+		t.Error("manifest should be enabled by the pwa preset")
+	}
+	if *windows {
+		t.Error("windows should be disabled by the pwa preset")
+	}
+}
+
+func TestApplyPresetErrors(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	if err := applyPreset(fs, ""); err != nil {
+		t.Errorf("applyPreset() with empty name error = %v", err)
+	}
+	if err := applyPreset(fs, "unknown"); err == nil {
+		t.Error("expected error for unknown preset")
+	}
+	if err := applyPreset(fs, "web"); err == nil {
+		t.Error("expected error when a preset flag is not defined")
+	}
+}
+
+func TestPresetNames(t *testing.T) {
+	want := []string{"all", "android", "apple", "minimal", "pwa", "web", "windows"}
+	if got := presetNames(); !slices.Equal(got, want) {
+		t.Errorf("presetNames() = %v, want %v", got, want)
+	}
+}
@@ -1,3 +1,5 @@
 module github.com/fathurrohman26/favicongen
 
 go 1.25.5
+
+require golang.org/x/image v0.25.0
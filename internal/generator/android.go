@@ -0,0 +1,16 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+)
+
+// GenerateAndroidChromeIcons renders every Android Chrome size with
+// AndroidOptions, named android-chrome-NxN.png as expected by common
+// deployment templates
+func (g *FaviconGenerator) GenerateAndroidChromeIcons(ctx context.Context) ([]string, error) {
+	if len(g.AndroidSizes) == 0 {
+		return nil, fmt.Errorf("no android-chrome icon sizes specified")
+	}
+	return g.renderSquares(ctx, g.AndroidSizes, g.AndroidOptions, g.Names.AndroidChromeIcon, "android-chrome icon")
+}
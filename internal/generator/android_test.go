@@ -0,0 +1,89 @@
+package generator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fathurrohman26/favicongen/processor"
+)
+
+func TestFaviconGeneratorGenerateAndroidChromeIcons(t *testing.T) {
+	_, sourcePath, outputDir, cleanup := createTestEnv(t)
+	defer cleanup()
+
+	opts := processor.ResizeOptions{Upscale: true}
+	mockProc := newMockProcessor()
+	gen := &FaviconGenerator{
+		Processor:      mockProc,
+		SourcePath:     sourcePath,
+		OutputDir:      outputDir,
+		AndroidSizes:   []int{192, 512},
+		AndroidOptions: opts,
+	}
+
+	paths, err := gen.GenerateAndroidChromeIcons(context.Background())
+	if err != nil {
+		t.Fatalf("GenerateAndroidChromeIcons() error = %v", err)
+	}
+
+	want := []string{
+		filepath.Join(outputDir, "android-chrome-192x192.png"),
+		filepath.Join(outputDir, "android-chrome-512x512.png"),
+	}
+	if len(paths) != len(want) {
+		t.Fatalf("got %d paths, want %d", len(paths), len(want))
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("paths[%d] = %q, want %q", i, paths[i], want[i])
+		}
+		if _, err := os.Stat(want[i]); os.IsNotExist(err) {
+			t.Errorf("expected file %s to exist", want[i])
+		}
+	}
+	for _, call := range mockProc.resizeCalls {
+		if call.opts != opts {
+			t.Errorf("resize %v called with %+v, want %+v", call.size, call.opts, opts)
+		}
+	}
+}
+
+func TestFaviconGeneratorGenerateAndroidChromeIconsNoSizes(t *testing.T) {
+	_, sourcePath, outputDir, cleanup := createTestEnv(t)
+	defer cleanup()
+
+	gen := &FaviconGenerator{Processor: newMockProcessor(), SourcePath: sourcePath, OutputDir: outputDir}
+	if _, err := gen.GenerateAndroidChromeIcons(context.Background()); err == nil {
+		t.Error("expected error without android-chrome sizes")
+	}
+}
+
+func TestFaviconGeneratorGenerateAndroidChromeIconsNamedTarget(t *testing.T) {
+	_, sourcePath, outputDir, cleanup := createTestEnv(t)
+	defer cleanup()
+
+	mockProc := newMockProcessor()
+	gen := &FaviconGenerator{
+		Processor:    mockProc,
+		SourcePath:   sourcePath,
+		OutputDir:    outputDir,
+		Targets:      []Target{{Size: processor.Square(192), Name: "android-chrome"}},
+		AndroidSizes: []int{192, 512},
+	}
+
+	paths, err := gen.GenerateAndroidChromeIcons(context.Background())
+	if err != nil {
+		t.Fatalf("GenerateAndroidChromeIcons() error = %v", err)
+	}
+
+	// The 192px icon is left to the target that writes the same file
+	want := filepath.Join(outputDir, "android-chrome-512x512.png")
+	if len(paths) != 1 || paths[0] != want {
+		t.Errorf("paths = %v, want [%s]", paths, want)
+	}
+	if len(mockProc.resizeCalls) != 1 {
+		t.Errorf("got %d resize calls, want 1", len(mockProc.resizeCalls))
+	}
+}
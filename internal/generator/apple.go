@@ -0,0 +1,23 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+)
+
+// AppleTouchIconSize is the size iOS uses for the default apple-touch-icon
+const AppleTouchIconSize = 180
+
+// GenerateAppleTouchIcons renders every apple-touch-icon size with
+// AppleTouchOptions. iOS shows transparent areas as black, so callers
+// normally set an opaque background.
+func (g *FaviconGenerator) GenerateAppleTouchIcons(ctx context.Context) ([]string, error) {
+	if len(g.AppleTouchSizes) == 0 {
+		return nil, fmt.Errorf("no apple-touch-icon sizes specified")
+	}
+	return g.renderSquares(ctx, g.AppleTouchSizes, g.AppleTouchOptions, g.appleTouchIconName, "apple-touch-icon")
+}
+
+func (g *FaviconGenerator) appleTouchIconName(size int) string {
+	return g.Names.AppleTouchIcon(size, g.AppleTouchRoot)
+}
@@ -0,0 +1,113 @@
+package generator
+
+import (
+	"context"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fathurrohman26/favicongen/processor"
+)
+
+func TestFaviconGeneratorGenerateAppleTouchIcons(t *testing.T) {
+	_, sourcePath, outputDir, cleanup := createTestEnv(t)
+	defer cleanup()
+
+	opts := processor.ResizeOptions{Background: color.White, Padding: processor.Padding{Value: 10, Percent: true}}
+	mockProc := newMockProcessor()
+	gen := &FaviconGenerator{
+		Processor:         mockProc,
+		SourcePath:        sourcePath,
+		OutputDir:         outputDir,
+		Targets:           SizeTargets(processor.Squares(16, 180)...),
+		SizeOptions:       map[processor.Size]processor.ResizeOptions{processor.Square(180): {}},
+		AppleTouchSizes:   []int{152, 180},
+		AppleTouchOptions: opts,
+		AppleTouchRoot:    true,
+	}
+
+	paths, err := gen.GenerateAppleTouchIcons(context.Background())
+	if err != nil {
+		t.Fatalf("GenerateAppleTouchIcons() error = %v", err)
+	}
+
+	want := []string{
+		filepath.Join(outputDir, "apple-touch-icon-152x152.png"),
+		filepath.Join(outputDir, "apple-touch-icon.png"),
+	}
+	if len(paths) != len(want) {
+		t.Fatalf("got %d paths, want %d", len(paths), len(want))
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("paths[%d] = %q, want %q", i, paths[i], want[i])
+		}
+		if _, err := os.Stat(want[i]); os.IsNotExist(err) {
+			t.Errorf("expected file %s to exist", want[i])
+		}
+	}
+
+	for _, call := range mockProc.resizeCalls {
+		if call.opts != opts {
+			t.Errorf("resize %v called with %+v, want apple-touch options", call.size, call.opts)
+		}
+	}
+}
+
+func TestFaviconGeneratorGenerateAppleTouchIconsOpaque(t *testing.T) {
+	tmpDir := t.TempDir()
+	sourcePath := filepath.Join(tmpDir, "source.png")
+	writeTestPNG(t, sourcePath, 64)
+
+	background := color.NRGBA{R: 0x12, G: 0x34, B: 0x56, A: 0xff}
+	gen := &FaviconGenerator{
+		Processor:       &processor.NativeProcessor{},
+		SourcePath:      sourcePath,
+		OutputDir:       tmpDir,
+		AppleTouchSizes: []int{180},
+		AppleTouchOptions: processor.ResizeOptions{
+			Background: background,
+			Padding:    processor.Padding{Value: 12, Percent: true},
+			Upscale:    true,
+		},
+	}
+
+	paths, err := gen.GenerateAppleTouchIcons(context.Background())
+	if err != nil {
+		t.Fatalf("GenerateAppleTouchIcons() error = %v", err)
+	}
+
+	f, err := os.Open(paths[0])
+	if err != nil {
+		t.Fatalf("failed to open icon: %v", err)
+	}
+	defer f.Close()
+
+	img, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("failed to decode icon: %v", err)
+	}
+	if got := img.Bounds().Size(); got.X != 180 || got.Y != 180 {
+		t.Errorf("icon size = %v, want 180x180", got)
+	}
+	if got := color.NRGBAModel.Convert(img.At(0, 0)); got != background {
+		t.Errorf("corner pixel = %v, want background %v", got, background)
+	}
+}
+
+func TestFaviconGeneratorGenerateAppleTouchIconsNoSizes(t *testing.T) {
+	_, sourcePath, outputDir, cleanup := createTestEnv(t)
+	defer cleanup()
+
+	gen := &FaviconGenerator{
+		Processor:  newMockProcessor(),
+		SourcePath: sourcePath,
+		OutputDir:  outputDir,
+	}
+
+	if _, err := gen.GenerateAppleTouchIcons(context.Background()); err == nil {
+		t.Error("expected error without apple-touch-icon sizes")
+	}
+}
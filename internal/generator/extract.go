@@ -0,0 +1,109 @@
+package generator
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+
+	"github.com/fathurrohman26/favicongen/processor"
+)
+
+// ExtractICO writes every image of an ICO file to outputDir, named like the
+// PNG favicons by names, and returns the written paths. When several entries
+// share the same dimensions, only the one with the highest bit depth is kept.
+func ExtractICO(icoPath, outputDir string, names NameTemplate) ([]string, error) {
+	entries, err := processor.ReadICO(icoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ICO file: %w", err)
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// Pick the best entry per dimension, keeping directory order
+	var order []processor.Size
+	best := make(map[processor.Size]processor.ICOEntry)
+	for _, e := range entries {
+		size := processor.Size{Width: e.Width, Height: e.Height}
+		current, seen := best[size]
+		if !seen {
+			order = append(order, size)
+		}
+		if !seen || e.BitCount > current.BitCount {
+			best[size] = e
+		}
+	}
+
+	jobs := make([]resizeJob, 0, len(order))
+	for _, size := range order {
+		jobs = append(jobs, resizeJob{outputPath: filepath.Join(outputDir, filepath.FromSlash(names.Favicon(size))), size: size})
+	}
+	if err := createOutputDirs(jobs); err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		img, err := best[job.size].Image()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s image: %w", job.size, err)
+		}
+
+		if err := writePNG(job.outputPath, img); err != nil {
+			return nil, err
+		}
+		paths = append(paths, job.outputPath)
+	}
+
+	return paths, nil
+}
+
+// extractLargestICOImage writes the largest image of an ICO file to a
+// temporary PNG and returns its path
+func extractLargestICOImage(icoPath string) (string, error) {
+	entries, err := processor.ReadICO(icoPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read ICO source: %w", err)
+	}
+
+	entry, err := processor.LargestICOEntry(entries)
+	if err != nil {
+		return "", err
+	}
+
+	img, err := entry.Image()
+	if err != nil {
+		return "", fmt.Errorf("failed to decode ICO source: %w", err)
+	}
+
+	f, err := os.CreateTemp("", "favicongen-source-*.png")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary source: %w", err)
+	}
+	f.Close()
+
+	if err := writePNG(f.Name(), img); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+
+	return f.Name(), nil
+}
+
+// writePNG encodes img as a PNG file at path
+func writePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+
+	return f.Close()
+}
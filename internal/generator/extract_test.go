@@ -0,0 +1,141 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fathurrohman26/favicongen/processor"
+)
+
+// writeTestICOFile creates an ICO file containing one entry per size
+func writeTestICOFile(t *testing.T, dir string, sizes []int) string {
+	t.Helper()
+
+	var pngPaths []string
+	for _, size := range sizes {
+		path := filepath.Join(dir, fmt.Sprintf("src-%d.png", size))
+		writeTestPNG(t, path, size)
+		pngPaths = append(pngPaths, path)
+	}
+
+	icoPath := filepath.Join(dir, "source.ico")
+	if err := processor.WriteICO(pngPaths, icoPath, processor.ICOEncodingLegacy); err != nil {
+		t.Fatalf("failed to write ICO: %v", err)
+	}
+	return icoPath
+}
+
+func TestExtractICO(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "favicongen-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	icoPath := writeTestICOFile(t, tmpDir, []int{16, 32, 64})
+	outputDir := filepath.Join(tmpDir, "extracted")
+
+	paths, err := ExtractICO(icoPath, outputDir, DefaultNameTemplate)
+	if err != nil {
+		t.Fatalf("ExtractICO() error = %v", err)
+	}
+
+	if len(paths) != 3 {
+		t.Fatalf("extracted %d files, want 3", len(paths))
+	}
+
+	for i, size := range []int{16, 32, 64} {
+		want := filepath.Join(outputDir, fmt.Sprintf("favicon-%dx%d.png", size, size))
+		if paths[i] != want {
+			t.Errorf("paths[%d] = %q, want %q", i, paths[i], want)
+		}
+
+		f, err := os.Open(paths[i])
+		if err != nil {
+			t.Fatalf("failed to open extracted file: %v", err)
+		}
+		cfg, err := png.DecodeConfig(f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("extracted file is not a PNG: %v", err)
+		}
+		if cfg.Width != size || cfg.Height != size {
+			t.Errorf("extracted size = %dx%d, want %dx%d", cfg.Width, cfg.Height, size, size)
+		}
+	}
+}
+
+func TestExtractICONameTemplate(t *testing.T) {
+	tmpDir := t.TempDir()
+	icoPath := writeTestICOFile(t, tmpDir, []int{16, 32})
+	outputDir := filepath.Join(tmpDir, "extracted")
+
+	paths, err := ExtractICO(icoPath, outputDir, "icons/{w}.png")
+	if err != nil {
+		t.Fatalf("ExtractICO() error = %v", err)
+	}
+
+	want := []string{filepath.Join(outputDir, "icons", "16.png"), filepath.Join(outputDir, "icons", "32.png")}
+	if len(paths) != len(want) {
+		t.Fatalf("got %d paths, want %d", len(paths), len(want))
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("paths[%d] = %q, want %q", i, paths[i], want[i])
+		}
+		if _, err := os.Stat(want[i]); err != nil {
+			t.Errorf("expected %s to be extracted", want[i])
+		}
+	}
+
+	if _, err := ExtractICO(icoPath, outputDir, "icon.png"); err == nil {
+		t.Error("expected error when every image gets the same name")
+	}
+}
+
+func TestExtractICOInvalidFile(t *testing.T) {
+	_, sourcePath, outputDir, cleanup := createTestEnv(t)
+	defer cleanup()
+
+	if _, err := ExtractICO(sourcePath, outputDir, DefaultNameTemplate); err == nil {
+		t.Error("expected error for non-ICO file")
+	}
+}
+
+func TestFaviconGeneratorGenerateFromICO(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "favicongen-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	icoPath := writeTestICOFile(t, tmpDir, []int{16, 48, 32})
+
+	mockProc := newMockProcessor()
+	gen := &FaviconGenerator{
+		Processor:  mockProc,
+		SourcePath: icoPath,
+		OutputDir:  filepath.Join(tmpDir, "output"),
+		Targets:    SizeTargets(processor.Squares(16, 32)...),
+	}
+
+	if _, err := gen.Generate(context.Background()); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if len(mockProc.resizeCalls) != 2 {
+		t.Fatalf("resize called %d times, want 2", len(mockProc.resizeCalls))
+	}
+
+	input := mockProc.resizeCalls[0].inputPath
+	if filepath.Ext(input) != ".png" {
+		t.Errorf("resize input = %q, want a PNG", input)
+	}
+	if _, err := os.Stat(input); !os.IsNotExist(err) {
+		t.Error("temporary source PNG was not removed")
+	}
+}
@@ -1,11 +1,17 @@
 package generator
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
 
-	"github.com/fathurrohman26/favicongen/internal/processor"
+	"github.com/fathurrohman26/favicongen/processor"
 )
 
 // FaviconGenerator handles favicon generation
@@ -13,7 +19,76 @@ type FaviconGenerator struct {
 	Processor  processor.Processor
 	SourcePath string
 	OutputDir  string
-	Sizes      []int
+
+	// Targets lists the PNG favicons rendered by Generate
+	Targets []Target
+
+	// Names builds the paths of all sized images; empty means
+	// DefaultNameTemplate
+	Names NameTemplate
+
+	// ResizeOptions is passed to every resize operation
+	ResizeOptions processor.ResizeOptions
+
+	// SizeOptions replaces ResizeOptions for individual output sizes, e.g. to
+	// pad and fill the apple-touch-icon while small favicons stay transparent
+	SizeOptions map[processor.Size]processor.ResizeOptions
+
+	// AppleTouchSizes lists the apple-touch-icon sizes rendered by
+	// GenerateAppleTouchIcons, using AppleTouchOptions instead of the
+	// per-size options
+	AppleTouchSizes   []int
+	AppleTouchOptions processor.ResizeOptions
+
+	// AppleTouchRoot writes the 180px apple-touch-icon as
+	// AppleTouchIconRootName regardless of Names
+	AppleTouchRoot bool
+
+	// AndroidSizes lists the android-chrome icon sizes rendered by
+	// GenerateAndroidChromeIcons with AndroidOptions
+	AndroidSizes   []int
+	AndroidOptions processor.ResizeOptions
+
+	// MaskableSizes lists the maskable icon sizes rendered by
+	// GenerateMaskableIcons with MaskableOptions
+	MaskableSizes   []int
+	MaskableOptions processor.ResizeOptions
+
+	// MonochromeSizes lists the monochrome icon sizes rendered by
+	// GenerateMonochromeIcons with MonochromeOptions
+	MonochromeSizes   []int
+	MonochromeOptions processor.ResizeOptions
+
+	// WindowsTileOptions is used to render the Windows tiles
+	WindowsTileOptions processor.ResizeOptions
+
+	// SplashOptions is used to render the iOS splash screens
+	SplashOptions processor.ResizeOptions
+
+	// SVGDarkColors recolors favicon.svg in dark mode; see GenerateSVGFavicon
+	SVGDarkColors SVGDarkColors
+
+	// ICOSizes lists the entries bundled into favicon.ico; they are rendered
+	// independently of Targets
+	ICOSizes []int
+
+	// ICOEncoding selects how ICO entries are encoded; when empty the
+	// processor's own ICO conversion is used
+	ICOEncoding processor.ICOEncoding
+
+	// Jobs limits how many resize operations run concurrently; zero or
+	// negative means GOMAXPROCS
+	Jobs int
+
+	// Timeout bounds each individual processor operation; zero means no limit
+	Timeout time.Duration
+}
+
+// resizeJob describes a single resize of the source image
+type resizeJob struct {
+	outputPath string
+	size       processor.Size
+	opts       processor.ResizeOptions
 }
 
 // GenerateResult contains the results of favicon generation
@@ -22,36 +97,260 @@ type GenerateResult struct {
 	ICOPath        string
 }
 
-// Generate creates all favicon files
-func (g *FaviconGenerator) Generate() (*GenerateResult, error) {
+// Generate creates all favicon files. Canceling ctx aborts running and
+// pending resize operations.
+func (g *FaviconGenerator) Generate(ctx context.Context) (*GenerateResult, error) {
 	// Create output directory
 	if err := os.MkdirAll(g.OutputDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create output directory: %w", err)
 	}
 
+	sourcePath, cleanup, err := g.prepareSource()
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
 	result := &GenerateResult{
-		GeneratedFiles: make([]string, 0, len(g.Sizes)),
+		GeneratedFiles: make([]string, 0, len(g.Targets)),
 	}
 
-	// Generate each size
-	for _, size := range g.Sizes {
-		outputPath := filepath.Join(g.OutputDir, fmt.Sprintf("favicon-%dx%d.png", size, size))
+	jobs := make([]resizeJob, 0, len(g.Targets))
+	for _, target := range g.Targets {
+		outputPath := g.outputPath(target.FileName(g.Names))
+		jobs = append(jobs, resizeJob{outputPath: outputPath, size: target.Size, opts: target.options(g.resizeOptions(target.Size))})
+		result.GeneratedFiles = append(result.GeneratedFiles, outputPath)
+	}
+	if err := createOutputDirs(jobs); err != nil {
+		return nil, err
+	}
 
-		if err := g.Processor.Resize(g.SourcePath, outputPath, size); err != nil {
-			return nil, fmt.Errorf("failed to generate %dx%d favicon: %w", size, size, err)
-		}
+	// Generate each size, collecting failures from all of them
+	jobErrs := g.runResizeJobs(ctx, sourcePath, jobs)
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("favicon generation canceled: %w", err)
+	}
 
-		result.GeneratedFiles = append(result.GeneratedFiles, outputPath)
+	var errs []error
+	for i, err := range jobErrs {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to generate %s favicon: %w", jobs[i].size, err))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
 	}
 
 	return result, nil
 }
 
-// GenerateICO creates a multi-resolution ICO file
-func (g *FaviconGenerator) GenerateICO(pngPaths []string) (string, error) {
+// runResizeJobs resizes the source for every job using a bounded worker pool.
+// Every job is attempted unless ctx is canceled; the returned errors are
+// indexed like jobs.
+func (g *FaviconGenerator) runResizeJobs(ctx context.Context, sourcePath string, jobs []resizeJob) []error {
+	workers := g.Jobs
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(jobs))
+
+	errs := make([]error, len(jobs))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+					continue
+				}
+
+				opCtx, cancel := g.operationContext(ctx)
+				errs[i] = g.Processor.Resize(opCtx, sourcePath, jobs[i].outputPath, jobs[i].size, jobs[i].opts)
+				cancel()
+			}
+		}()
+	}
+
+	for i := range jobs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return errs
+}
+
+// renderSet renders every size with the same options into OutputDir, naming
+// the files with fileName. Sizes whose file a named target already renders
+// are left to that target. Failures are reported per size and labeled kind.
+func (g *FaviconGenerator) renderSet(ctx context.Context, sizes []processor.Size, opts processor.ResizeOptions, fileName func(size processor.Size) string, kind string) ([]string, error) {
+	if err := os.MkdirAll(g.OutputDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	sourcePath, cleanup, err := g.prepareSource()
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	paths := make([]string, 0, len(sizes))
+	jobs := make([]resizeJob, 0, len(sizes))
+	for _, size := range sizes {
+		name := fileName(size)
+		if g.writtenByTarget(name, size) {
+			continue
+		}
+		outputPath := g.outputPath(name)
+		jobs = append(jobs, resizeJob{outputPath: outputPath, size: size, opts: opts})
+		paths = append(paths, outputPath)
+	}
+	if err := createOutputDirs(jobs); err != nil {
+		return nil, err
+	}
+
+	jobErrs := g.runResizeJobs(ctx, sourcePath, jobs)
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("%s generation canceled: %w", kind, err)
+	}
+
+	var errs []error
+	for i, err := range jobErrs {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to generate %s %s: %w", jobs[i].size, kind, err))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return paths, nil
+}
+
+// renderSquares is renderSet for square icons named by their edge length
+func (g *FaviconGenerator) renderSquares(ctx context.Context, sizes []int, opts processor.ResizeOptions, fileName func(size int) string, kind string) ([]string, error) {
+	name := func(size processor.Size) string { return fileName(size.Width) }
+	return g.renderSet(ctx, processor.Squares(sizes...), opts, name, kind)
+}
+
+// outputPath converts a slash-separated name from Names into a path inside
+// OutputDir
+func (g *FaviconGenerator) outputPath(name string) string {
+	return filepath.Join(g.OutputDir, filepath.FromSlash(name))
+}
+
+// createOutputDirs creates the directory of every job's output. Two jobs
+// writing the same file mean the names cannot tell them apart.
+func createOutputDirs(jobs []resizeJob) error {
+	seen := make(map[string]processor.Size, len(jobs))
+	for _, job := range jobs {
+		if size, ok := seen[job.outputPath]; ok {
+			return fmt.Errorf("%s and %s are both named %s", size, job.size, job.outputPath)
+		}
+		seen[job.outputPath] = job.size
+
+		if err := os.MkdirAll(filepath.Dir(job.outputPath), 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+	return nil
+}
+
+// resizeOptions returns the options for an output size, preferring a
+// per-size override
+func (g *FaviconGenerator) resizeOptions(size processor.Size) processor.ResizeOptions {
+	if opts, ok := g.SizeOptions[size]; ok {
+		return opts
+	}
+	return g.ResizeOptions
+}
+
+// operationContext derives the context for a single processor operation,
+// applying the configured timeout
+func (g *FaviconGenerator) operationContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if g.Timeout > 0 {
+		return context.WithTimeout(ctx, g.Timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// prepareSource returns the path to use as resize input. ICO sources are
+// replaced by a temporary PNG of their largest embedded image.
+func (g *FaviconGenerator) prepareSource() (string, func(), error) {
+	if !strings.EqualFold(filepath.Ext(g.SourcePath), ".ico") {
+		return g.SourcePath, func() {}, nil
+	}
+
+	path, err := extractLargestICOImage(g.SourcePath)
+	if err != nil {
+		return "", nil, err
+	}
+	return path, func() { os.Remove(path) }, nil
+}
+
+// GenerateICO renders every ICO size from the source and bundles them into a
+// multi-resolution ICO file
+func (g *FaviconGenerator) GenerateICO(ctx context.Context) (string, error) {
+	if len(g.ICOSizes) == 0 {
+		return "", fmt.Errorf("no ICO sizes specified")
+	}
+
+	if err := os.MkdirAll(g.OutputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	sourcePath, cleanup, err := g.prepareSource()
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
+	tmpDir, err := os.MkdirTemp("", "favicongen-ico-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	pngPaths := make([]string, 0, len(g.ICOSizes))
+	jobs := make([]resizeJob, 0, len(g.ICOSizes))
+	for _, size := range g.ICOSizes {
+		pngPath := filepath.Join(tmpDir, fmt.Sprintf("ico-%dx%d.png", size, size))
+		jobs = append(jobs, resizeJob{outputPath: pngPath, size: processor.Square(size), opts: g.resizeOptions(processor.Square(size))})
+		pngPaths = append(pngPaths, pngPath)
+	}
+	if err := createOutputDirs(jobs); err != nil {
+		return "", err
+	}
+
+	jobErrs := g.runResizeJobs(ctx, sourcePath, jobs)
+	if err := ctx.Err(); err != nil {
+		return "", fmt.Errorf("ICO generation canceled: %w", err)
+	}
+
+	var errs []error
+	for i, err := range jobErrs {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to render %s ICO entry: %w", jobs[i].size, err))
+		}
+	}
+	if len(errs) > 0 {
+		return "", errors.Join(errs...)
+	}
+
 	icoPath := filepath.Join(g.OutputDir, "favicon.ico")
 
-	if err := g.Processor.ConvertToICO(pngPaths, icoPath); err != nil {
+	if g.ICOEncoding != "" {
+		err = processor.WriteICO(pngPaths, icoPath, g.ICOEncoding)
+	} else {
+		opCtx, cancel := g.operationContext(ctx)
+		err = g.Processor.ConvertToICO(opCtx, pngPaths, icoPath)
+		cancel()
+	}
+	if err != nil {
 		return "", fmt.Errorf("failed to generate ICO file: %w", err)
 	}
 
@@ -1,10 +1,21 @@
 package generator
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/fathurrohman26/favicongen/processor"
 )
 
 // MockProcessor is a mock implementation of the Processor interface for testing
@@ -13,14 +24,21 @@ type MockProcessor struct {
 	available   bool
 	resizeErr   error
 	icoErr      error
+	failSizes   map[int]bool
+	resizeDelay time.Duration
 	resizeCalls []resizeCall
 	icoCalls    []icoCall
+
+	mu        sync.Mutex
+	active    int
+	maxActive int
 }
 
 type resizeCall struct {
 	inputPath  string
 	outputPath string
-	size       int
+	size       processor.Size
+	opts       processor.ResizeOptions
 }
 
 type icoCall struct {
@@ -36,15 +54,44 @@ func (m *MockProcessor) IsAvailable() bool {
 	return m.available
 }
 
-func (m *MockProcessor) Resize(inputPath, outputPath string, size int) error {
-	m.resizeCalls = append(m.resizeCalls, resizeCall{inputPath, outputPath, size})
+func (m *MockProcessor) Capabilities() processor.Capabilities {
+	return processor.Capabilities{
+		InputFormats:  []string{"png", "svg"},
+		OutputFormats: []string{"png", "ico"},
+		ICO:           true,
+		Upscale:       true,
+	}
+}
+
+func (m *MockProcessor) Resize(ctx context.Context, inputPath, outputPath string, size processor.Size, opts processor.ResizeOptions) error {
+	m.mu.Lock()
+	m.resizeCalls = append(m.resizeCalls, resizeCall{inputPath, outputPath, size, opts})
+	m.active++
+	m.maxActive = max(m.maxActive, m.active)
+	m.mu.Unlock()
+
+	defer func() {
+		m.mu.Lock()
+		m.active--
+		m.mu.Unlock()
+	}()
+
+	select {
+	case <-time.After(m.resizeDelay):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
 	if m.resizeErr != nil {
 		return m.resizeErr
 	}
+	if m.failSizes[size.Width] {
+		return fmt.Errorf("mock resize error for %v", size)
+	}
 	return os.WriteFile(outputPath, []byte("mock png data"), 0644)
 }
 
-func (m *MockProcessor) ConvertToICO(inputPaths []string, outputPath string) error {
+func (m *MockProcessor) ConvertToICO(ctx context.Context, inputPaths []string, outputPath string) error {
 	m.icoCalls = append(m.icoCalls, icoCall{inputPaths, outputPath})
 	if m.icoErr != nil {
 		return m.icoErr
@@ -83,10 +130,10 @@ func TestFaviconGeneratorGenerate(t *testing.T) {
 		Processor:  mockProc,
 		SourcePath: sourcePath,
 		OutputDir:  outputDir,
-		Sizes:      []int{16, 32, 48},
+		Targets:    SizeTargets(processor.Squares(16, 32, 48)...),
 	}
 
-	result, err := gen.Generate()
+	result, err := gen.Generate(context.Background())
 	if err != nil {
 		t.Fatalf("Generate() error = %v", err)
 	}
@@ -109,15 +156,62 @@ func TestFaviconGeneratorGenerate(t *testing.T) {
 		}
 	})
 
+	t.Run("passes resize options", func(t *testing.T) {
+		proc := newMockProcessor()
+		gen := &FaviconGenerator{
+			Processor:  proc,
+			SourcePath: sourcePath,
+			OutputDir:  outputDir,
+			Targets:    SizeTargets(processor.Squares(16, 32)...),
+			ResizeOptions: processor.ResizeOptions{
+				Fit:     processor.FitCover,
+				Gravity: processor.GravityWest,
+				Upscale: true,
+			},
+		}
+		if _, err := gen.Generate(context.Background()); err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		for _, call := range proc.resizeCalls {
+			if call.opts != gen.ResizeOptions {
+				t.Errorf("resize %v called with %+v, want %+v", call.size, call.opts, gen.ResizeOptions)
+			}
+		}
+	})
+
+	t.Run("applies per-size options", func(t *testing.T) {
+		proc := newMockProcessor()
+		padded := processor.ResizeOptions{Padding: processor.Padding{Value: 12, Percent: true}, Background: color.White}
+		gen := &FaviconGenerator{
+			Processor:   proc,
+			SourcePath:  sourcePath,
+			OutputDir:   outputDir,
+			Targets:     SizeTargets(processor.Squares(16, 180)...),
+			SizeOptions: map[processor.Size]processor.ResizeOptions{processor.Square(180): padded},
+		}
+		if _, err := gen.Generate(context.Background()); err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		for _, call := range proc.resizeCalls {
+			want := processor.ResizeOptions{}
+			if call.size == processor.Square(180) {
+				want = padded
+			}
+			if call.opts != want {
+				t.Errorf("resize %v called with %+v, want %+v", call.size, call.opts, want)
+			}
+		}
+	})
+
 	t.Run("creates nested output directory", func(t *testing.T) {
 		nestedDir := filepath.Join(tmpDir, "deep", "nested", "output")
 		gen2 := &FaviconGenerator{
 			Processor:  newMockProcessor(),
 			SourcePath: sourcePath,
 			OutputDir:  nestedDir,
-			Sizes:      []int{16},
+			Targets:    SizeTargets(processor.Squares(16)...),
 		}
-		if _, err := gen2.Generate(); err != nil {
+		if _, err := gen2.Generate(context.Background()); err != nil {
 			t.Fatalf("Generate() error = %v", err)
 		}
 		if _, err := os.Stat(nestedDir); os.IsNotExist(err) {
@@ -126,6 +220,97 @@ func TestFaviconGeneratorGenerate(t *testing.T) {
 	})
 }
 
+func TestFaviconGeneratorGenerateRectangular(t *testing.T) {
+	tmpDir := t.TempDir()
+	sourcePath := filepath.Join(tmpDir, "source.png")
+	writeTestPNG(t, sourcePath, 64)
+
+	sizes := []processor.Size{processor.Square(16), {Width: 310, Height: 150}}
+	gen := &FaviconGenerator{
+		Processor:  &processor.NativeProcessor{},
+		SourcePath: sourcePath,
+		OutputDir:  tmpDir,
+		Targets:    SizeTargets(sizes...),
+	}
+
+	result, err := gen.Generate(context.Background())
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	for i, size := range sizes {
+		want := filepath.Join(tmpDir, DefaultNameTemplate.Favicon(size))
+		if result.GeneratedFiles[i] != want {
+			t.Errorf("GeneratedFiles[%d] = %q, want %q", i, result.GeneratedFiles[i], want)
+		}
+
+		f, err := os.Open(want)
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", want, err)
+		}
+		img, err := png.Decode(f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("failed to decode %s: %v", want, err)
+		}
+		if got := img.Bounds().Size(); got.X != size.Width || got.Y != size.Height {
+			t.Errorf("%s size = %dx%d, want %v", want, got.X, got.Y, size)
+		}
+	}
+}
+
+func TestFaviconGeneratorGenerateNameTemplate(t *testing.T) {
+	_, sourcePath, outputDir, cleanup := createTestEnv(t)
+	defer cleanup()
+
+	mockProc := newMockProcessor()
+	gen := &FaviconGenerator{
+		Processor:  mockProc,
+		SourcePath: sourcePath,
+		OutputDir:  outputDir,
+		Targets:    SizeTargets(processor.Squares(16, 32)...),
+		Names:      "icons/{name}-{w}x{h}.{ext}",
+	}
+
+	result, err := gen.Generate(context.Background())
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	want := []string{
+		filepath.Join(outputDir, "icons", "favicon-16x16.png"),
+		filepath.Join(outputDir, "icons", "favicon-32x32.png"),
+	}
+	for i := range want {
+		if result.GeneratedFiles[i] != want[i] {
+			t.Errorf("GeneratedFiles[%d] = %q, want %q", i, result.GeneratedFiles[i], want[i])
+		}
+	}
+	if info, err := os.Stat(filepath.Join(outputDir, "icons")); err != nil || !info.IsDir() {
+		t.Errorf("expected icons directory to be created: %v", err)
+	}
+}
+
+func TestFaviconGeneratorGenerateNameTemplateCollision(t *testing.T) {
+	_, sourcePath, outputDir, cleanup := createTestEnv(t)
+	defer cleanup()
+
+	mockProc := newMockProcessor()
+	gen := &FaviconGenerator{
+		Processor:  mockProc,
+		SourcePath: sourcePath,
+		OutputDir:  outputDir,
+		Targets:    []Target{{Size: processor.Square(16), Name: "icon.png"}, {Size: processor.Square(32), Name: "icon.png"}},
+	}
+
+	if _, err := gen.Generate(context.Background()); err == nil {
+		t.Fatal("expected error for targets named the same")
+	}
+	if len(mockProc.resizeCalls) != 0 {
+		t.Errorf("got %d resize calls, want none", len(mockProc.resizeCalls))
+	}
+}
+
 func TestFaviconGeneratorGenerateError(t *testing.T) {
 	_, sourcePath, outputDir, cleanup := createTestEnv(t)
 	defer cleanup()
@@ -140,76 +325,263 @@ func TestFaviconGeneratorGenerateError(t *testing.T) {
 		Processor:  mockProc,
 		SourcePath: sourcePath,
 		OutputDir:  outputDir,
-		Sizes:      []int{16, 32},
+		Targets:    SizeTargets(processor.Squares(16, 32)...),
 	}
 
-	if _, err := gen.Generate(); err == nil {
+	if _, err := gen.Generate(context.Background()); err == nil {
 		t.Error("expected error from Generate()")
 	}
 }
 
-func TestFaviconGeneratorGenerateICO(t *testing.T) {
-	tmpDir, err := os.MkdirTemp("", "favicongen-test-*")
+func TestFaviconGeneratorGenerateConcurrent(t *testing.T) {
+	_, sourcePath, outputDir, cleanup := createTestEnv(t)
+	defer cleanup()
+
+	sizes := []int{512, 16, 256, 32, 180, 48, 128, 64}
+	mockProc := newMockProcessor()
+	mockProc.resizeDelay = 10 * time.Millisecond
+
+	gen := &FaviconGenerator{
+		Processor:  mockProc,
+		SourcePath: sourcePath,
+		OutputDir:  outputDir,
+		Targets:    SizeTargets(processor.Squares(sizes...)...),
+		Jobs:       3,
+	}
+
+	result, err := gen.Generate(context.Background())
 	if err != nil {
-		t.Fatalf("failed to create temp dir: %v", err)
+		t.Fatalf("Generate() error = %v", err)
 	}
-	defer os.RemoveAll(tmpDir)
+
+	t.Run("preserves size order in results", func(t *testing.T) {
+		for i, size := range sizes {
+			want := filepath.Join(outputDir, fmt.Sprintf("favicon-%dx%d.png", size, size))
+			if result.GeneratedFiles[i] != want {
+				t.Errorf("GeneratedFiles[%d] = %q, want %q", i, result.GeneratedFiles[i], want)
+			}
+		}
+	})
+
+	t.Run("bounds concurrency", func(t *testing.T) {
+		if mockProc.maxActive > 3 {
+			t.Errorf("max concurrent resizes = %d, want <= 3", mockProc.maxActive)
+		}
+		if mockProc.maxActive < 2 {
+			t.Errorf("max concurrent resizes = %d, want parallel execution", mockProc.maxActive)
+		}
+	})
+}
+
+func TestFaviconGeneratorGenerateAggregatesErrors(t *testing.T) {
+	_, sourcePath, outputDir, cleanup := createTestEnv(t)
+	defer cleanup()
 
 	mockProc := newMockProcessor()
+	mockProc.failSizes = map[int]bool{32: true, 128: true}
+
 	gen := &FaviconGenerator{
-		Processor: mockProc,
-		OutputDir: tmpDir,
-		Sizes:     []int{16, 32, 48},
+		Processor:  mockProc,
+		SourcePath: sourcePath,
+		OutputDir:  outputDir,
+		Targets:    SizeTargets(processor.Squares(16, 32, 64, 128)...),
+		Jobs:       2,
 	}
 
-	pngPaths := []string{
-		filepath.Join(tmpDir, "favicon-16x16.png"),
-		filepath.Join(tmpDir, "favicon-32x32.png"),
-		filepath.Join(tmpDir, "favicon-48x48.png"),
+	_, err := gen.Generate(context.Background())
+	if err == nil {
+		t.Fatal("expected error from Generate()")
 	}
 
-	icoPath, err := gen.GenerateICO(pngPaths)
-	if err != nil {
-		t.Fatalf("GenerateICO() error = %v", err)
+	if len(mockProc.resizeCalls) != 4 {
+		t.Errorf("resize called %d times, want 4 (all sizes attempted)", len(mockProc.resizeCalls))
 	}
 
-	expectedPath := filepath.Join(tmpDir, "favicon.ico")
-	if icoPath != expectedPath {
-		t.Errorf("GenerateICO() path = %q, want %q", icoPath, expectedPath)
+	msg := err.Error()
+	for _, want := range []string{"32x32", "128x128"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("error %q does not mention %s", msg, want)
+		}
+	}
+	if strings.Index(msg, "32x32") > strings.Index(msg, "128x128") {
+		t.Errorf("errors are not reported in size order: %q", msg)
 	}
+}
+
+func TestFaviconGeneratorGenerateCanceled(t *testing.T) {
+	_, sourcePath, outputDir, cleanup := createTestEnv(t)
+	defer cleanup()
+
+	mockProc := newMockProcessor()
+	mockProc.resizeDelay = time.Minute
 
-	if len(mockProc.icoCalls) != 1 {
-		t.Fatalf("ICO called %d times, want 1", len(mockProc.icoCalls))
+	gen := &FaviconGenerator{
+		Processor:  mockProc,
+		SourcePath: sourcePath,
+		OutputDir:  outputDir,
+		Targets:    SizeTargets(processor.Squares(16, 32, 48, 64)...),
+		Jobs:       1,
 	}
 
-	call := mockProc.icoCalls[0]
-	if len(call.inputPaths) != 3 {
-		t.Errorf("ICO inputPaths count = %d, want 3", len(call.inputPaths))
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := gen.Generate(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Generate() error = %v, want %v", err, context.Canceled)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Generate() took %v after cancellation", elapsed)
+	}
+	if len(mockProc.resizeCalls) != 1 {
+		t.Errorf("resize called %d times, want 1 (pending sizes skipped)", len(mockProc.resizeCalls))
 	}
 }
 
-func TestFaviconGeneratorGenerateICOError(t *testing.T) {
-	tmpDir, err := os.MkdirTemp("", "favicongen-test-*")
-	if err != nil {
-		t.Fatalf("failed to create temp dir: %v", err)
+func TestFaviconGeneratorGenerateTimeout(t *testing.T) {
+	_, sourcePath, outputDir, cleanup := createTestEnv(t)
+	defer cleanup()
+
+	mockProc := newMockProcessor()
+	mockProc.resizeDelay = time.Minute
+
+	gen := &FaviconGenerator{
+		Processor:  mockProc,
+		SourcePath: sourcePath,
+		OutputDir:  outputDir,
+		Targets:    SizeTargets(processor.Squares(16, 32)...),
+		Timeout:    20 * time.Millisecond,
 	}
-	defer os.RemoveAll(tmpDir)
 
-	mockProc := &MockProcessor{
-		name:      "mock",
-		available: true,
-		icoErr:    errors.New("mock ico error"),
+	_, err := gen.Generate(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Generate() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if len(mockProc.resizeCalls) != 2 {
+		t.Errorf("resize called %d times, want 2 (timeout applies per operation)", len(mockProc.resizeCalls))
 	}
+}
 
+func TestFaviconGeneratorGenerateICO(t *testing.T) {
+	_, sourcePath, outputDir, cleanup := createTestEnv(t)
+	defer cleanup()
+
+	mockProc := newMockProcessor()
 	gen := &FaviconGenerator{
-		Processor: mockProc,
-		OutputDir: tmpDir,
-		Sizes:     []int{16, 32},
+		Processor:  mockProc,
+		SourcePath: sourcePath,
+		OutputDir:  outputDir,
+		Targets:    SizeTargets(processor.Squares(16, 32, 48)...),
+		ICOSizes:   []int{16, 24, 32, 64},
+	}
+
+	icoPath, err := gen.GenerateICO(context.Background())
+	if err != nil {
+		t.Fatalf("GenerateICO() error = %v", err)
 	}
 
-	if _, err := gen.GenerateICO([]string{"a.png", "b.png"}); err == nil {
-		t.Error("expected error from GenerateICO()")
+	expectedPath := filepath.Join(outputDir, "favicon.ico")
+	if icoPath != expectedPath {
+		t.Errorf("GenerateICO() path = %q, want %q", icoPath, expectedPath)
 	}
+
+	t.Run("renders every ICO size", func(t *testing.T) {
+		if len(mockProc.resizeCalls) != 4 {
+			t.Fatalf("resize called %d times, want 4", len(mockProc.resizeCalls))
+		}
+		var sizes []int
+		for _, call := range mockProc.resizeCalls {
+			sizes = append(sizes, call.size.Width)
+		}
+		slices.Sort(sizes)
+		if !slices.Equal(sizes, []int{16, 24, 32, 64}) {
+			t.Errorf("resized sizes = %v, want [16 24 32 64]", sizes)
+		}
+	})
+
+	t.Run("does not write ICO entries to the output directory", func(t *testing.T) {
+		entries, err := os.ReadDir(outputDir)
+		if err != nil {
+			t.Fatalf("failed to read output dir: %v", err)
+		}
+		if len(entries) != 1 || entries[0].Name() != "favicon.ico" {
+			t.Errorf("output dir contains %d entries, want only favicon.ico", len(entries))
+		}
+	})
+
+	t.Run("bundles rendered entries", func(t *testing.T) {
+		if len(mockProc.icoCalls) != 1 {
+			t.Fatalf("ICO called %d times, want 1", len(mockProc.icoCalls))
+		}
+		if got := len(mockProc.icoCalls[0].inputPaths); got != 4 {
+			t.Errorf("ICO inputPaths count = %d, want 4", got)
+		}
+	})
+}
+
+func TestFaviconGeneratorGenerateICOError(t *testing.T) {
+	_, sourcePath, outputDir, cleanup := createTestEnv(t)
+	defer cleanup()
+
+	t.Run("conversion error", func(t *testing.T) {
+		gen := &FaviconGenerator{
+			Processor: &MockProcessor{
+				name:      "mock",
+				available: true,
+				icoErr:    errors.New("mock ico error"),
+			},
+			SourcePath: sourcePath,
+			OutputDir:  outputDir,
+			ICOSizes:   []int{16, 32},
+		}
+		if _, err := gen.GenerateICO(context.Background()); err == nil {
+			t.Error("expected error from GenerateICO()")
+		}
+	})
+
+	t.Run("resize error", func(t *testing.T) {
+		gen := &FaviconGenerator{
+			Processor: &MockProcessor{
+				name:      "mock",
+				available: true,
+				resizeErr: errors.New("mock resize error"),
+			},
+			SourcePath: sourcePath,
+			OutputDir:  outputDir,
+			ICOSizes:   []int{16},
+		}
+		if _, err := gen.GenerateICO(context.Background()); err == nil {
+			t.Error("expected error from GenerateICO()")
+		}
+	})
+
+	t.Run("duplicate sizes", func(t *testing.T) {
+		mockProc := newMockProcessor()
+		gen := &FaviconGenerator{
+			Processor:  mockProc,
+			SourcePath: sourcePath,
+			OutputDir:  outputDir,
+			ICOSizes:   []int{16, 16, 32},
+		}
+		if _, err := gen.GenerateICO(context.Background()); err == nil {
+			t.Error("expected error for duplicate ICO sizes")
+		}
+		if len(mockProc.resizeCalls) != 0 {
+			t.Errorf("got %d resize calls, want none", len(mockProc.resizeCalls))
+		}
+	})
+
+	t.Run("no sizes", func(t *testing.T) {
+		gen := &FaviconGenerator{
+			Processor:  newMockProcessor(),
+			SourcePath: sourcePath,
+			OutputDir:  outputDir,
+		}
+		if _, err := gen.GenerateICO(context.Background()); err == nil {
+			t.Error("expected error from GenerateICO()")
+		}
+	})
 }
 
 func TestGenerateResultFields(t *testing.T) {
@@ -226,3 +598,64 @@ func TestGenerateResultFields(t *testing.T) {
 		t.Errorf("ICOPath = %q, want %q", result.ICOPath, "favicon.ico")
 	}
 }
+
+func TestFaviconGeneratorGenerateICOWithEncoding(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "favicongen-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	sourcePath := filepath.Join(tmpDir, "source.png")
+	writeTestPNG(t, sourcePath, 64)
+
+	gen := &FaviconGenerator{
+		Processor:   &processor.NativeProcessor{},
+		SourcePath:  sourcePath,
+		OutputDir:   tmpDir,
+		ICOSizes:    []int{16, 32},
+		ICOEncoding: processor.ICOEncodingLegacy,
+	}
+
+	icoPath, err := gen.GenerateICO(context.Background())
+	if err != nil {
+		t.Fatalf("GenerateICO() error = %v", err)
+	}
+
+	data, err := os.ReadFile(icoPath)
+	if err != nil {
+		t.Fatalf("failed to read ICO: %v", err)
+	}
+	if len(data) < 6 || data[2] != 1 || data[4] != 2 {
+		t.Fatalf("ICO header = %v, want type 1 with 2 entries", data[:6])
+	}
+
+	entries, err := processor.ReadICO(icoPath)
+	if err != nil {
+		t.Fatalf("failed to decode ICO: %v", err)
+	}
+	for i, e := range entries {
+		if e.Format != processor.ICOFormatBMP {
+			t.Errorf("entry[%d] format = %s, want bmp", i, e.Format)
+		}
+	}
+}
+
+// writeTestPNG creates a real square PNG file for tests that need decodable input
+func writeTestPNG(t *testing.T, path string, size int) {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, size, size))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create PNG: %v", err)
+	}
+	defer f.Close()
+
+	if err := png.Encode(f, img); err != nil {
+		t.Fatalf("failed to encode PNG: %v", err)
+	}
+}
@@ -7,9 +7,36 @@ import (
 
 // HTMLTagsConfig contains configuration for HTML tag generation
 type HTMLTagsConfig struct {
-	Sizes           []int
+	Targets         []Target
 	IncludeManifest bool
 	ThemeColor      string
+
+	// Names must match the template the images were generated with
+	Names NameTemplate
+
+	// IncludeICO links favicon.ico as the default favicon
+	IncludeICO bool
+
+	// IncludeSVG links favicon.svg ahead of the PNG fallbacks
+	IncludeSVG bool
+
+	// AppleTouchSizes lists generated apple-touch-icon files; when empty the
+	// first square PNG favicon of at least 180px is referenced instead
+	AppleTouchSizes []int
+
+	// AppleTouchRoot must match the generator's AppleTouchRoot
+	AppleTouchRoot bool
+
+	// SplashScreens links the iOS startup images from SplashScreens
+	SplashScreens bool
+
+	// MaskIconColor is the Safari pinned tab color; when empty no mask-icon
+	// tag is emitted
+	MaskIconColor string
+
+	// TileColor is the Windows tile color; when set the browserconfig.xml
+	// meta tags are emitted
+	TileColor string
 }
 
 // GenerateHTMLTags creates HTML link tags for favicons
@@ -17,30 +44,65 @@ func GenerateHTMLTags(config *HTMLTagsConfig) string {
 	var tags []string
 
 	// Add favicon.ico link (default browser favicon)
-	tags = append(tags, `<link rel="icon" href="/favicon.ico" sizes="any">`)
+	if config.IncludeICO {
+		tags = append(tags, `<link rel="icon" href="/favicon.ico" sizes="any">`)
+	}
+
+	// Add the scalable favicon; browsers pick the first icon they support
+	if config.IncludeSVG {
+		tags = append(tags, fmt.Sprintf(`<link rel="icon" type="image/svg+xml" href="/%s">`, SVGFaviconFileName))
+	}
 
 	// Add PNG favicons for each size
-	for _, size := range config.Sizes {
-		tag := fmt.Sprintf(`<link rel="icon" type="image/png" sizes="%dx%d" href="/favicon-%dx%d.png">`,
-			size, size, size, size)
+	for _, target := range config.Targets {
+		tag := fmt.Sprintf(`<link rel="icon" type="image/png" sizes="%s" href="/%s">`,
+			target.Size, target.FileName(config.Names))
 		tags = append(tags, tag)
 	}
 
-	// Add Apple Touch Icon (typically 180x180)
-	for _, size := range config.Sizes {
-		if size == 180 || size >= 180 {
-			tag := fmt.Sprintf(`<link rel="apple-touch-icon" sizes="%dx%d" href="/favicon-%dx%d.png">`,
-				size, size, size, size)
+	// Add Apple Touch Icons, falling back to a PNG favicon (typically 180x180)
+	if len(config.AppleTouchSizes) > 0 {
+		for _, size := range config.AppleTouchSizes {
+			tag := fmt.Sprintf(`<link rel="apple-touch-icon" sizes="%dx%d" href="/%s">`,
+				size, size, config.Names.AppleTouchIcon(size, config.AppleTouchRoot))
 			tags = append(tags, tag)
-			break
+		}
+	} else {
+		for _, target := range config.Targets {
+			if target.Size.IsSquare() && target.Size.Width >= AppleTouchIconSize {
+				tag := fmt.Sprintf(`<link rel="apple-touch-icon" sizes="%s" href="/%s">`,
+					target.Size, target.FileName(config.Names))
+				tags = append(tags, tag)
+				break
+			}
+		}
+	}
+
+	// Add iOS startup images, one per device and orientation
+	if config.SplashScreens {
+		for _, s := range SplashScreens() {
+			tags = append(tags, fmt.Sprintf(`<link rel="apple-touch-startup-image" media="%s" href="/%s">`,
+				s.Media(), config.Names.SplashScreen(s.Size())))
 		}
 	}
 
+	// Add Safari pinned tab icon
+	if config.MaskIconColor != "" {
+		tags = append(tags, fmt.Sprintf(`<link rel="mask-icon" href="/%s" color="%s">`,
+			SafariPinnedTabFileName, config.MaskIconColor))
+	}
+
 	// Add manifest link
 	if config.IncludeManifest {
 		tags = append(tags, `<link rel="manifest" href="/manifest.webmanifest">`)
 	}
 
+	// Add Windows tile meta tags
+	if config.TileColor != "" {
+		tags = append(tags, fmt.Sprintf(`<meta name="msapplication-TileColor" content="%s">`, config.TileColor))
+		tags = append(tags, fmt.Sprintf(`<meta name="msapplication-config" content="/%s">`, BrowserConfigFileName))
+	}
+
 	// Add theme color meta tag
 	if config.ThemeColor != "" {
 		tags = append(tags, fmt.Sprintf(`<meta name="theme-color" content="%s">`, config.ThemeColor))
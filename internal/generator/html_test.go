@@ -3,6 +3,8 @@ package generator
 import (
 	"strings"
 	"testing"
+
+	"github.com/fathurrohman26/favicongen/processor"
 )
 
 func TestGenerateHTMLTags(t *testing.T) {
@@ -15,9 +17,10 @@ func TestGenerateHTMLTags(t *testing.T) {
 		{
 			name: "basic sizes",
 			config: &HTMLTagsConfig{
-				Sizes:           []int{16, 32},
+				Targets:         SizeTargets(processor.Squares(16, 32)...),
 				IncludeManifest: false,
 				ThemeColor:      "",
+				IncludeICO:      true,
 			},
 			wantContains: []string{
 				`<link rel="icon" href="/favicon.ico" sizes="any">`,
@@ -27,12 +30,26 @@ func TestGenerateHTMLTags(t *testing.T) {
 			wantNotContain: []string{
 				`manifest`,
 				`theme-color`,
+				`msapplication`,
+				`apple-touch-startup-image`,
+			},
+		},
+		{
+			name: "without ICO",
+			config: &HTMLTagsConfig{
+				Targets: SizeTargets(processor.Squares(16, 32)...),
+			},
+			wantContains: []string{
+				`<link rel="icon" type="image/png" sizes="16x16" href="/favicon-16x16.png">`,
+			},
+			wantNotContain: []string{
+				`favicon.ico`,
 			},
 		},
 		{
 			name: "with manifest",
 			config: &HTMLTagsConfig{
-				Sizes:           []int{16, 32},
+				Targets:         SizeTargets(processor.Squares(16, 32)...),
 				IncludeManifest: true,
 				ThemeColor:      "",
 			},
@@ -43,7 +60,7 @@ func TestGenerateHTMLTags(t *testing.T) {
 		{
 			name: "with theme color",
 			config: &HTMLTagsConfig{
-				Sizes:           []int{16},
+				Targets:         SizeTargets(processor.Squares(16)...),
 				IncludeManifest: false,
 				ThemeColor:      "#ff0000",
 			},
@@ -54,7 +71,7 @@ func TestGenerateHTMLTags(t *testing.T) {
 		{
 			name: "with apple touch icon size 180",
 			config: &HTMLTagsConfig{
-				Sizes:           []int{16, 32, 180},
+				Targets:         SizeTargets(processor.Squares(16, 32, 180)...),
 				IncludeManifest: false,
 				ThemeColor:      "",
 			},
@@ -65,7 +82,7 @@ func TestGenerateHTMLTags(t *testing.T) {
 		{
 			name: "with apple touch icon fallback (size >= 180)",
 			config: &HTMLTagsConfig{
-				Sizes:           []int{16, 32, 192},
+				Targets:         SizeTargets(processor.Squares(16, 32, 192)...),
 				IncludeManifest: false,
 				ThemeColor:      "",
 			},
@@ -73,10 +90,67 @@ func TestGenerateHTMLTags(t *testing.T) {
 				`<link rel="apple-touch-icon" sizes="192x192" href="/favicon-192x192.png">`,
 			},
 		},
+		{
+			name: "rectangular sizes report true dimensions",
+			config: &HTMLTagsConfig{
+				Targets: SizeTargets(processor.Size{Width: 310, Height: 150}, processor.Square(180)),
+			},
+			wantContains: []string{
+				`<link rel="icon" type="image/png" sizes="310x150" href="/favicon-310x150.png">`,
+				`<link rel="apple-touch-icon" sizes="180x180" href="/favicon-180x180.png">`,
+			},
+			wantNotContain: []string{
+				`<link rel="apple-touch-icon" sizes="310x150"`,
+			},
+		},
+		{
+			name: "with splash screens",
+			config: &HTMLTagsConfig{
+				Targets:       SizeTargets(processor.Squares(16)...),
+				SplashScreens: true,
+			},
+			wantContains: []string{
+				`<link rel="apple-touch-startup-image" media="(device-width: 1024px) and (device-height: 1366px) and (-webkit-device-pixel-ratio: 2) and (orientation: portrait)" href="/splash/apple-splash-2048x2732.png">`,
+				`<link rel="apple-touch-startup-image" media="(device-width: 320px) and (device-height: 568px) and (-webkit-device-pixel-ratio: 2) and (orientation: landscape)" href="/splash/apple-splash-1136x640.png">`,
+			},
+		},
+		{
+			name: "with name template",
+			config: &HTMLTagsConfig{
+				Targets:         SizeTargets(processor.Squares(32, 192)...),
+				Names:           "icons/{name}-{w}x{h}.{ext}",
+				AppleTouchSizes: []int{152, 180},
+				SplashScreens:   true,
+				IncludeICO:      true,
+			},
+			wantContains: []string{
+				`<link rel="icon" href="/favicon.ico" sizes="any">`,
+				`<link rel="icon" type="image/png" sizes="32x32" href="/icons/favicon-32x32.png">`,
+				`<link rel="apple-touch-icon" sizes="152x152" href="/icons/apple-touch-icon-152x152.png">`,
+				`<link rel="apple-touch-icon" sizes="180x180" href="/icons/apple-touch-icon-180x180.png">`,
+				`href="/icons/splash/apple-splash-2048x2732.png"`,
+			},
+		},
+		{
+			name: "with named targets",
+			config: &HTMLTagsConfig{
+				Targets: []Target{
+					{Size: processor.Square(16)},
+					{Size: processor.Square(180), Name: "apple-touch-icon.png"},
+					{Size: processor.Square(192), Name: "android-chrome"},
+				},
+			},
+			wantContains: []string{
+				`<link rel="icon" type="image/png" sizes="16x16" href="/favicon-16x16.png">`,
+				`<link rel="icon" type="image/png" sizes="180x180" href="/apple-touch-icon.png">`,
+				`<link rel="icon" type="image/png" sizes="192x192" href="/android-chrome-192x192.png">`,
+				`<link rel="apple-touch-icon" sizes="180x180" href="/apple-touch-icon.png">`,
+			},
+		},
 		{
 			name: "no apple touch icon when sizes < 180",
 			config: &HTMLTagsConfig{
-				Sizes:           []int{16, 32, 64},
+				Targets:         SizeTargets(processor.Squares(16, 32, 64)...),
 				IncludeManifest: false,
 				ThemeColor:      "",
 			},
@@ -84,12 +158,59 @@ func TestGenerateHTMLTags(t *testing.T) {
 				`apple-touch-icon`,
 			},
 		},
+		{
+			name: "with generated apple touch icons",
+			config: &HTMLTagsConfig{
+				Targets:         SizeTargets(processor.Squares(16, 32, 180)...),
+				AppleTouchSizes: []int{152, 167, 180},
+				AppleTouchRoot:  true,
+			},
+			wantContains: []string{
+				`<link rel="apple-touch-icon" sizes="152x152" href="/apple-touch-icon-152x152.png">`,
+				`<link rel="apple-touch-icon" sizes="167x167" href="/apple-touch-icon-167x167.png">`,
+				`<link rel="apple-touch-icon" sizes="180x180" href="/apple-touch-icon.png">`,
+			},
+			wantNotContain: []string{
+				`<link rel="apple-touch-icon" sizes="180x180" href="/favicon-180x180.png">`,
+			},
+		},
+		{
+			name: "with mask icon",
+			config: &HTMLTagsConfig{
+				Targets:       SizeTargets(processor.Squares(16)...),
+				MaskIconColor: "#5bbad5",
+			},
+			wantContains: []string{
+				`<link rel="mask-icon" href="/safari-pinned-tab.svg" color="#5bbad5">`,
+			},
+		},
+		{
+			name: "without mask icon",
+			config: &HTMLTagsConfig{
+				Targets: SizeTargets(processor.Squares(16)...),
+			},
+			wantNotContain: []string{
+				`mask-icon`,
+			},
+		},
+		{
+			name: "with windows tiles",
+			config: &HTMLTagsConfig{
+				Targets:   SizeTargets(processor.Squares(16)...),
+				TileColor: "#da532c",
+			},
+			wantContains: []string{
+				`<meta name="msapplication-TileColor" content="#da532c">`,
+				`<meta name="msapplication-config" content="/browserconfig.xml">`,
+			},
+		},
 		{
 			name: "full configuration",
 			config: &HTMLTagsConfig{
-				Sizes:           []int{16, 32, 64, 180, 512},
+				Targets:         SizeTargets(processor.Squares(16, 32, 64, 180, 512)...),
 				IncludeManifest: true,
 				ThemeColor:      "#ffffff",
+				IncludeICO:      true,
 			},
 			wantContains: []string{
 				`<link rel="icon" href="/favicon.ico" sizes="any">`,
@@ -124,11 +245,29 @@ func TestGenerateHTMLTags(t *testing.T) {
 	}
 }
 
+func TestGenerateHTMLTagsSVGBeforePNG(t *testing.T) {
+	got := GenerateHTMLTags(&HTMLTagsConfig{Targets: SizeTargets(processor.Squares(16, 32)...), IncludeSVG: true})
+
+	svg := strings.Index(got, `<link rel="icon" type="image/svg+xml" href="/favicon.svg">`)
+	png := strings.Index(got, `type="image/png"`)
+	if svg < 0 {
+		t.Fatalf("missing SVG favicon link:\n%s", got)
+	}
+	if svg > png {
+		t.Errorf("SVG favicon link should precede PNG links:\n%s", got)
+	}
+
+	if got := GenerateHTMLTags(&HTMLTagsConfig{Targets: SizeTargets(processor.Squares(16)...)}); strings.Contains(got, "svg+xml") {
+		t.Errorf("unexpected SVG favicon link:\n%s", got)
+	}
+}
+
 func TestGenerateHTMLTagsEmptySizes(t *testing.T) {
 	config := &HTMLTagsConfig{
-		Sizes:           []int{},
+		Targets:         SizeTargets(processor.Squares()...),
 		IncludeManifest: false,
 		ThemeColor:      "",
+		IncludeICO:      true,
 	}
 
 	got := GenerateHTMLTags(config)
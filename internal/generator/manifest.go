@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+
+	"github.com/fathurrohman26/favicongen/processor"
 )
 
 // ManifestConfig contains configuration for the web app manifest
@@ -20,7 +23,22 @@ type ManifestConfig struct {
 	BackgroundColor string
 	Categories      []string
 	IconPath        string
-	IconSizes       []int
+	Targets         []Target
+
+	// Names must match the template the icons were generated with
+	Names NameTemplate
+
+	// AndroidSizes lists generated android-chrome icons. They are listed
+	// first and replace the PNG favicons of the same size.
+	AndroidSizes []int
+
+	// MaskableSizes lists generated maskable icons, listed separately with
+	// purpose "maskable"
+	MaskableSizes []int
+
+	// MonochromeSizes lists generated monochrome icons, listed with purpose
+	// "monochrome"
+	MonochromeSizes []int
 }
 
 // Manifest represents a web app manifest
@@ -63,21 +81,54 @@ func GenerateManifest(config *ManifestConfig, outputDir string) (string, error)
 	}
 
 	// Add icons
-	for _, size := range config.IconSizes {
+	for _, size := range config.AndroidSizes {
+		manifest.Icons = append(manifest.Icons, Icon{
+			Src:     config.Names.AndroidChromeIcon(size),
+			Sizes:   processor.Square(size).String(),
+			Type:    "image/png",
+			Purpose: "any",
+		})
+	}
+
+	for _, target := range config.Targets {
+		size := target.Size
+		if size.IsSquare() && slices.Contains(config.AndroidSizes, size.Width) {
+			continue
+		}
+
 		icon := Icon{
-			Src:   fmt.Sprintf("favicon-%dx%d.png", size, size),
-			Sizes: fmt.Sprintf("%dx%d", size, size),
+			Src:   target.FileName(config.Names),
+			Sizes: size.String(),
 			Type:  "image/png",
 		}
 
-		// Mark larger icons as suitable for any purpose
-		if size >= 192 {
-			icon.Purpose = "any maskable"
+		// Mark larger icons as suitable for any purpose. They are not padded
+		// into the safe zone, so they must not be offered as maskable.
+		if size.Min() >= 192 {
+			icon.Purpose = "any"
 		}
 
 		manifest.Icons = append(manifest.Icons, icon)
 	}
 
+	for _, size := range config.MaskableSizes {
+		manifest.Icons = append(manifest.Icons, Icon{
+			Src:     config.Names.MaskableIcon(size),
+			Sizes:   processor.Square(size).String(),
+			Type:    "image/png",
+			Purpose: "maskable",
+		})
+	}
+
+	for _, size := range config.MonochromeSizes {
+		manifest.Icons = append(manifest.Icons, Icon{
+			Src:     config.Names.MonochromeIcon(size),
+			Sizes:   processor.Square(size).String(),
+			Type:    "image/png",
+			Purpose: "monochrome",
+		})
+	}
+
 	// Marshal to JSON
 	data, err := json.MarshalIndent(manifest, "", "  ")
 	if err != nil {
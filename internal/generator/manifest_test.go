@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/fathurrohman26/favicongen/processor"
 )
 
 func createManifestTestDir(t *testing.T) (string, func()) {
@@ -44,7 +46,7 @@ func TestGenerateManifestBasic(t *testing.T) {
 		ThemeColor:      "#ffffff",
 		BackgroundColor: "#000000",
 		Categories:      []string{"utilities", "productivity"},
-		IconSizes:       []int{192, 512},
+		Targets:         SizeTargets(processor.Squares(192, 512)...),
 	}
 
 	manifestPath, err := GenerateManifest(config, tmpDir)
@@ -72,9 +74,9 @@ func TestGenerateManifestMinimal(t *testing.T) {
 	defer cleanup()
 
 	config := &ManifestConfig{
-		StartURL:  "/",
-		Display:   "standalone",
-		IconSizes: []int{192},
+		StartURL: "/",
+		Display:  "standalone",
+		Targets:  SizeTargets(processor.Squares(192)...),
 	}
 
 	manifestPath, err := GenerateManifest(config, tmpDir)
@@ -97,9 +99,9 @@ func TestGenerateManifestIcons(t *testing.T) {
 	defer cleanup()
 
 	config := &ManifestConfig{
-		StartURL:  "/",
-		Display:   "standalone",
-		IconSizes: []int{48, 192, 512},
+		StartURL: "/",
+		Display:  "standalone",
+		Targets:  SizeTargets(processor.Squares(48, 192, 512)...),
 	}
 
 	manifestPath, err := GenerateManifest(config, tmpDir)
@@ -130,16 +132,180 @@ func TestGenerateManifestIcons(t *testing.T) {
 	}
 
 	// Check large icon (192px, has purpose)
-	if manifest.Icons[1].Purpose != "any maskable" {
-		t.Errorf("icon[1].Purpose = %q, want %q", manifest.Icons[1].Purpose, "any maskable")
+	if manifest.Icons[1].Purpose != "any" {
+		t.Errorf("icon[1].Purpose = %q, want %q", manifest.Icons[1].Purpose, "any")
+	}
+}
+
+func TestGenerateManifestRectangularIcons(t *testing.T) {
+	tmpDir, cleanup := createManifestTestDir(t)
+	defer cleanup()
+
+	config := &ManifestConfig{
+		StartURL: "/",
+		Display:  "standalone",
+		Targets:  SizeTargets(processor.Size{Width: 620, Height: 300}, processor.Size{Width: 620, Height: 100}),
+	}
+
+	manifestPath, err := GenerateManifest(config, tmpDir)
+	if err != nil {
+		t.Fatalf("GenerateManifest() error = %v", err)
+	}
+
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		t.Fatalf("failed to read manifest: %v", err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("failed to parse manifest: %v", err)
+	}
+
+	want := []Icon{
+		{Src: "favicon-620x300.png", Sizes: "620x300", Type: "image/png", Purpose: "any"},
+		{Src: "favicon-620x100.png", Sizes: "620x100", Type: "image/png"},
+	}
+	if len(manifest.Icons) != len(want) {
+		t.Fatalf("got %d icons, want %d", len(manifest.Icons), len(want))
+	}
+	for i := range want {
+		if manifest.Icons[i] != want[i] {
+			t.Errorf("icon[%d] = %+v, want %+v", i, manifest.Icons[i], want[i])
+		}
+	}
+}
+
+func TestGenerateManifestAndroidChromeIcons(t *testing.T) {
+	tmpDir, cleanup := createManifestTestDir(t)
+	defer cleanup()
+
+	config := &ManifestConfig{
+		StartURL:     "/",
+		Display:      "standalone",
+		Targets:      SizeTargets(processor.Squares(32, 192, 256)...),
+		AndroidSizes: []int{192, 512},
+	}
+
+	manifestPath, err := GenerateManifest(config, tmpDir)
+	if err != nil {
+		t.Fatalf("GenerateManifest() error = %v", err)
+	}
+
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		t.Fatalf("failed to read manifest: %v", err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("failed to parse manifest: %v", err)
+	}
+
+	want := []Icon{
+		{Src: "android-chrome-192x192.png", Sizes: "192x192", Type: "image/png", Purpose: "any"},
+		{Src: "android-chrome-512x512.png", Sizes: "512x512", Type: "image/png", Purpose: "any"},
+		{Src: "favicon-32x32.png", Sizes: "32x32", Type: "image/png"},
+		{Src: "favicon-256x256.png", Sizes: "256x256", Type: "image/png", Purpose: "any"},
+	}
+	if len(manifest.Icons) != len(want) {
+		t.Fatalf("got %d icons, want %d: %+v", len(manifest.Icons), len(want), manifest.Icons)
+	}
+	for i := range want {
+		if manifest.Icons[i] != want[i] {
+			t.Errorf("icon[%d] = %+v, want %+v", i, manifest.Icons[i], want[i])
+		}
+	}
+}
+
+func TestGenerateManifestPurposeIcons(t *testing.T) {
+	tmpDir, cleanup := createManifestTestDir(t)
+	defer cleanup()
+
+	config := &ManifestConfig{
+		StartURL:        "/",
+		Display:         "standalone",
+		Targets:         SizeTargets(processor.Squares(192, 512)...),
+		MaskableSizes:   []int{192, 512},
+		MonochromeSizes: []int{192},
+	}
+
+	manifestPath, err := GenerateManifest(config, tmpDir)
+	if err != nil {
+		t.Fatalf("GenerateManifest() error = %v", err)
+	}
+
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		t.Fatalf("failed to read manifest: %v", err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("failed to parse manifest: %v", err)
+	}
+
+	want := []Icon{
+		{Src: "favicon-192x192.png", Sizes: "192x192", Type: "image/png", Purpose: "any"},
+		{Src: "favicon-512x512.png", Sizes: "512x512", Type: "image/png", Purpose: "any"},
+		{Src: "favicon-maskable-192x192.png", Sizes: "192x192", Type: "image/png", Purpose: "maskable"},
+		{Src: "favicon-maskable-512x512.png", Sizes: "512x512", Type: "image/png", Purpose: "maskable"},
+		{Src: "favicon-monochrome-192x192.png", Sizes: "192x192", Type: "image/png", Purpose: "monochrome"},
+	}
+	if len(manifest.Icons) != len(want) {
+		t.Fatalf("got %d icons, want %d", len(manifest.Icons), len(want))
+	}
+	for i := range want {
+		if manifest.Icons[i] != want[i] {
+			t.Errorf("icon[%d] = %+v, want %+v", i, manifest.Icons[i], want[i])
+		}
+	}
+}
+
+func TestGenerateManifestNameTemplate(t *testing.T) {
+	tmpDir, cleanup := createManifestTestDir(t)
+	defer cleanup()
+
+	config := &ManifestConfig{
+		StartURL:      "/",
+		Display:       "standalone",
+		Targets:       []Target{{Size: processor.Square(32)}, {Size: processor.Square(256), Name: "logo.png"}},
+		Names:         "icons/{name}-{w}x{h}.{ext}",
+		AndroidSizes:  []int{192},
+		MaskableSizes: []int{512},
+	}
+
+	manifestPath, err := GenerateManifest(config, tmpDir)
+	if err != nil {
+		t.Fatalf("GenerateManifest() error = %v", err)
+	}
+
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		t.Fatalf("failed to read manifest: %v", err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("failed to parse manifest: %v", err)
+	}
+
+	want := []string{"icons/android-chrome-192x192.png", "icons/favicon-32x32.png", "logo.png", "icons/favicon-maskable-512x512.png"}
+	if len(manifest.Icons) != len(want) {
+		t.Fatalf("got %d icons, want %d", len(manifest.Icons), len(want))
+	}
+	for i := range want {
+		if manifest.Icons[i].Src != want[i] {
+			t.Errorf("icon[%d].Src = %q, want %q", i, manifest.Icons[i].Src, want[i])
+		}
 	}
 }
 
 func TestGenerateManifestInvalidDir(t *testing.T) {
 	config := &ManifestConfig{
-		StartURL:  "/",
-		Display:   "standalone",
-		IconSizes: []int{192},
+		StartURL: "/",
+		Display:  "standalone",
+		Targets:  SizeTargets(processor.Squares(192)...),
 	}
 
 	_, err := GenerateManifest(config, "/nonexistent/path/that/cannot/exist")
@@ -156,7 +322,7 @@ func TestManifestCategories(t *testing.T) {
 		StartURL:   "/",
 		Display:    "standalone",
 		Categories: []string{"games", "entertainment", "social"},
-		IconSizes:  []int{192},
+		Targets:    SizeTargets(processor.Squares(192)...),
 	}
 
 	manifestPath, err := GenerateManifest(config, tmpDir)
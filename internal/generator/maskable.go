@@ -0,0 +1,30 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/fathurrohman26/favicongen/processor"
+)
+
+// MaskableSafeZone is the diameter of the circle, as a fraction of the icon
+// size, that Android guarantees to show for maskable icons
+const MaskableSafeZone = 0.8
+
+// MaskablePadding returns the padding that keeps the source inside the safe
+// zone. The source box is inscribed in the safe circle, so even the corners
+// of a square logo survive any mask shape.
+func MaskablePadding() processor.Padding {
+	return processor.Padding{Value: 50 * (1 - MaskableSafeZone/math.Sqrt2), Percent: true}
+}
+
+// GenerateMaskableIcons renders every maskable size with MaskableOptions.
+// The launcher mask may reveal any part of the canvas, so callers normally
+// combine MaskablePadding with an opaque background.
+func (g *FaviconGenerator) GenerateMaskableIcons(ctx context.Context) ([]string, error) {
+	if len(g.MaskableSizes) == 0 {
+		return nil, fmt.Errorf("no maskable icon sizes specified")
+	}
+	return g.renderSquares(ctx, g.MaskableSizes, g.MaskableOptions, g.Names.MaskableIcon, "maskable icon")
+}
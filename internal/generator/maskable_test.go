@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"context"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fathurrohman26/favicongen/processor"
+)
+
+func TestFaviconGeneratorGenerateMaskableIcons(t *testing.T) {
+	tmpDir := t.TempDir()
+	sourcePath := filepath.Join(tmpDir, "source.png")
+	writeTestPNG(t, sourcePath, 512)
+
+	background := color.NRGBA{R: 0x20, G: 0x40, B: 0x60, A: 0xff}
+	gen := &FaviconGenerator{
+		Processor:     &processor.NativeProcessor{},
+		SourcePath:    sourcePath,
+		OutputDir:     tmpDir,
+		MaskableSizes: []int{192},
+		MaskableOptions: processor.ResizeOptions{
+			Padding:    MaskablePadding(),
+			Background: background,
+		},
+	}
+
+	paths, err := gen.GenerateMaskableIcons(context.Background())
+	if err != nil {
+		t.Fatalf("GenerateMaskableIcons() error = %v", err)
+	}
+	if want := filepath.Join(tmpDir, "favicon-maskable-192x192.png"); len(paths) != 1 || paths[0] != want {
+		t.Fatalf("paths = %v, want [%s]", paths, want)
+	}
+
+	f, err := os.Open(paths[0])
+	if err != nil {
+		t.Fatalf("failed to open icon: %v", err)
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("failed to decode icon: %v", err)
+	}
+
+	// Every source pixel must lie inside the safe circle, and the canvas
+	// outside the source must be the opaque background
+	center := 96.0
+	radius := 192 * MaskableSafeZone / 2
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if c.A != 0xff {
+				t.Fatalf("pixel (%d,%d) = %v, want opaque", x, y, c)
+			}
+			if c == background {
+				continue
+			}
+			if d := math.Hypot(float64(x)+0.5-center, float64(y)+0.5-center); d > radius+1 {
+				t.Fatalf("source pixel (%d,%d) lies %.1fpx from the center, outside the %.1fpx safe zone", x, y, d, radius)
+			}
+		}
+	}
+	if c := color.NRGBAModel.Convert(img.At(96, 96)); c == background {
+		t.Error("center pixel should show the source")
+	}
+}
+
+func TestFaviconGeneratorGenerateMaskableIconsNoSizes(t *testing.T) {
+	_, sourcePath, outputDir, cleanup := createTestEnv(t)
+	defer cleanup()
+
+	gen := &FaviconGenerator{
+		Processor:  newMockProcessor(),
+		SourcePath: sourcePath,
+		OutputDir:  outputDir,
+	}
+
+	if _, err := gen.GenerateMaskableIcons(context.Background()); err == nil {
+		t.Error("expected error without maskable sizes")
+	}
+}
@@ -0,0 +1,16 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+)
+
+// GenerateMonochromeIcons renders every monochrome size with
+// MonochromeOptions, which should enable Monochrome to produce the alpha
+// silhouette used by themed icons
+func (g *FaviconGenerator) GenerateMonochromeIcons(ctx context.Context) ([]string, error) {
+	if len(g.MonochromeSizes) == 0 {
+		return nil, fmt.Errorf("no monochrome icon sizes specified")
+	}
+	return g.renderSquares(ctx, g.MonochromeSizes, g.MonochromeOptions, g.Names.MonochromeIcon, "monochrome icon")
+}
@@ -0,0 +1,42 @@
+package generator
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/fathurrohman26/favicongen/processor"
+)
+
+func TestFaviconGeneratorGenerateMonochromeIcons(t *testing.T) {
+	_, sourcePath, outputDir, cleanup := createTestEnv(t)
+	defer cleanup()
+
+	opts := processor.ResizeOptions{Monochrome: true, Threshold: 0.5}
+	mockProc := newMockProcessor()
+	gen := &FaviconGenerator{
+		Processor:         mockProc,
+		SourcePath:        sourcePath,
+		OutputDir:         outputDir,
+		MonochromeSizes:   []int{192, 512},
+		MonochromeOptions: opts,
+	}
+
+	paths, err := gen.GenerateMonochromeIcons(context.Background())
+	if err != nil {
+		t.Fatalf("GenerateMonochromeIcons() error = %v", err)
+	}
+
+	want := []string{
+		filepath.Join(outputDir, "favicon-monochrome-192x192.png"),
+		filepath.Join(outputDir, "favicon-monochrome-512x512.png"),
+	}
+	if len(paths) != len(want) || paths[0] != want[0] || paths[1] != want[1] {
+		t.Errorf("paths = %v, want %v", paths, want)
+	}
+	for _, call := range mockProc.resizeCalls {
+		if call.opts != opts {
+			t.Errorf("resize %v called with %+v, want %+v", call.size, call.opts, opts)
+		}
+	}
+}
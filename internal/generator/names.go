@@ -0,0 +1,174 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fathurrohman26/favicongen/processor"
+)
+
+// Base names of the sized images, substituted for {name}
+const (
+	FaviconName       = "favicon"
+	AppleTouchName    = "apple-touch-icon"
+	AndroidChromeName = "android-chrome"
+	MaskableName      = "favicon-maskable"
+	MonochromeName    = "favicon-monochrome"
+	WindowsTileName   = "mstile"
+	SplashScreenName  = SplashScreenDir + "/apple-splash"
+)
+
+// AppleTouchIconRootName is the file iOS requests from the site root when a
+// page has no apple-touch-icon tag
+const AppleTouchIconRootName = "apple-touch-icon.png"
+
+// DefaultNameTemplate reproduces the classic favicon-32x32.png names
+const DefaultNameTemplate NameTemplate = "{name}-{w}x{h}.{ext}"
+
+// NameTemplate builds the slash-separated path, relative to the output
+// directory, of every sized image. It may contain {name}, {w}, {h}, {size}
+// (WxH) and {ext}. The empty template is DefaultNameTemplate.
+type NameTemplate string
+
+var placeholderPattern = regexp.MustCompile(`\{[^{}]*\}`)
+
+// ParseNameTemplate validates a naming template. The template must end in
+// .{ext} or .png and stay inside the output directory. Templates that give
+// two images of a run the same file are rejected by CheckFileNames.
+func ParseNameTemplate(s string) (NameTemplate, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return DefaultNameTemplate, nil
+	}
+
+	for _, p := range placeholderPattern.FindAllString(s, -1) {
+		switch p {
+		case "{name}", "{w}", "{h}", "{size}", "{ext}":
+		default:
+			return "", fmt.Errorf("unknown placeholder %s in name template %q (expected {name}, {w}, {h}, {size} or {ext})", p, s)
+		}
+	}
+	if !strings.HasSuffix(s, ".{ext}") && !strings.HasSuffix(s, ".png") {
+		return "", fmt.Errorf("name template %q must end in .{ext}", s)
+	}
+
+	clean := path.Clean(strings.ReplaceAll(s, `\`, "/"))
+	if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("name template %q must stay inside the output directory", s)
+	}
+
+	return NameTemplate(s), nil
+}
+
+// Path returns the path of the named image for a size; every generator,
+// the HTML tags and the manifest derive their file names from it
+func (t NameTemplate) Path(name string, size processor.Size) string {
+	if t == "" {
+		t = DefaultNameTemplate
+	}
+	r := strings.NewReplacer(
+		"{name}", name,
+		"{w}", strconv.Itoa(size.Width),
+		"{h}", strconv.Itoa(size.Height),
+		"{size}", size.String(),
+		"{ext}", "png",
+	)
+	return path.Clean(r.Replace(string(t)))
+}
+
+// Favicon returns the path of the PNG favicon for a size
+func (t NameTemplate) Favicon(size processor.Size) string {
+	return t.Path(FaviconName, size)
+}
+
+// AppleTouchIcon returns the path of the apple-touch-icon for a size. With
+// root set, the 180px icon is AppleTouchIconRootName instead of following
+// the template.
+func (t NameTemplate) AppleTouchIcon(size int, root bool) string {
+	if root && size == AppleTouchIconSize {
+		return AppleTouchIconRootName
+	}
+	return t.Path(AppleTouchName, processor.Square(size))
+}
+
+// AndroidChromeIcon returns the path of the Android Chrome icon for a size
+func (t NameTemplate) AndroidChromeIcon(size int) string {
+	return t.Path(AndroidChromeName, processor.Square(size))
+}
+
+// MaskableIcon returns the path of the maskable icon for a size
+func (t NameTemplate) MaskableIcon(size int) string {
+	return t.Path(MaskableName, processor.Square(size))
+}
+
+// MonochromeIcon returns the path of the monochrome icon for a size
+func (t NameTemplate) MonochromeIcon(size int) string {
+	return t.Path(MonochromeName, processor.Square(size))
+}
+
+// WindowsTile returns the path of a Windows tile image
+func (t NameTemplate) WindowsTile(size processor.Size) string {
+	return t.Path(WindowsTileName, size)
+}
+
+// SplashScreen returns the path of an iOS startup image
+func (t NameTemplate) SplashScreen(size processor.Size) string {
+	return t.Path(SplashScreenName, size)
+}
+
+// imageFile is a sized image one of the generators writes
+type imageFile struct {
+	name string
+	kind string
+	size processor.Size
+}
+
+// CheckFileNames reports an error when two images share a file. It covers
+// Targets and the apple-touch-icon, android-chrome, maskable and monochrome
+// sizes, plus the Windows tiles and splash screens when they are generated,
+// so callers can reject a conflicting configuration before writing anything.
+// A named target with the size and file of a set entry replaces that entry
+// instead of conflicting with it.
+func (g *FaviconGenerator) CheckFileNames(windowsTiles, splashScreens bool) error {
+	var files []imageFile
+	for _, t := range g.Targets {
+		files = append(files, imageFile{t.FileName(g.Names), "favicon", t.Size})
+	}
+	add := func(name, kind string, size processor.Size) {
+		if !g.writtenByTarget(name, size) {
+			files = append(files, imageFile{name, kind, size})
+		}
+	}
+	squares := func(sizes []int, fileName func(int) string, kind string) {
+		for _, size := range sizes {
+			add(fileName(size), kind, processor.Square(size))
+		}
+	}
+	squares(g.AppleTouchSizes, g.appleTouchIconName, "apple-touch-icon")
+	squares(g.AndroidSizes, g.Names.AndroidChromeIcon, "android-chrome icon")
+	squares(g.MaskableSizes, g.Names.MaskableIcon, "maskable icon")
+	squares(g.MonochromeSizes, g.Names.MonochromeIcon, "monochrome icon")
+	if windowsTiles {
+		for _, tile := range WindowsTiles {
+			add(g.Names.WindowsTile(tile.Size), "windows tile", tile.Size)
+		}
+	}
+	if splashScreens {
+		for _, s := range SplashScreens() {
+			add(g.Names.SplashScreen(s.Size()), "splash screen", s.Size())
+		}
+	}
+
+	seen := make(map[string]imageFile, len(files))
+	for _, f := range files {
+		name := path.Clean(f.name)
+		if prev, ok := seen[name]; ok {
+			return fmt.Errorf("%s %s and %s %s are both named %s", prev.kind, prev.size, f.kind, f.size, name)
+		}
+		seen[name] = f
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/fathurrohman26/favicongen/processor"
+)
+
+func TestParseNameTemplate(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    NameTemplate
+		wantErr bool
+	}{
+		{input: "", want: DefaultNameTemplate},
+		{input: " icons/{name}-{w}x{h}.{ext} ", want: "icons/{name}-{w}x{h}.{ext}"},
+		{input: "{name}_{size}.png", want: "{name}_{size}.png"},
+		{input: "{name}.{ext}", want: "{name}.{ext}"},
+		{input: "icon-{w}.png", want: "icon-{w}.png"},
+		{input: "icons/{w}.png", want: "icons/{w}.png"},
+		{input: "icon-{size}.{ext}", want: "icon-{size}.{ext}"},
+		{input: "{name}-{w}x{h}.jpg", wantErr: true},
+		{input: "{name}-{width}.{ext}", wantErr: true},
+		{input: "/icons/{name}-{w}.{ext}", wantErr: true},
+		{input: "../{name}-{w}.{ext}", wantErr: true},
+		{input: "icons/../../{name}-{w}.{ext}", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseNameTemplate(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseNameTemplate(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseNameTemplate(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNameTemplatePaths(t *testing.T) {
+	rect := processor.Size{Width: 310, Height: 150}
+
+	tests := []struct {
+		name     string
+		template NameTemplate
+		got      func(NameTemplate) string
+		want     string
+	}{
+		{"default favicon", "", func(n NameTemplate) string { return n.Favicon(processor.Square(32)) }, "favicon-32x32.png"},
+		{"default apple 180", "", func(n NameTemplate) string { return n.AppleTouchIcon(180, false) }, "apple-touch-icon-180x180.png"},
+		{"default apple 180 at root", "", func(n NameTemplate) string { return n.AppleTouchIcon(180, true) }, "apple-touch-icon.png"},
+		{"default apple 152 at root", "", func(n NameTemplate) string { return n.AppleTouchIcon(152, true) }, "apple-touch-icon-152x152.png"},
+		{"default android", "", func(n NameTemplate) string { return n.AndroidChromeIcon(192) }, "android-chrome-192x192.png"},
+		{"default maskable", "", func(n NameTemplate) string { return n.MaskableIcon(192) }, "favicon-maskable-192x192.png"},
+		{"default monochrome", "", func(n NameTemplate) string { return n.MonochromeIcon(512) }, "favicon-monochrome-512x512.png"},
+		{"default tile", "", func(n NameTemplate) string { return n.WindowsTile(rect) }, "mstile-310x150.png"},
+		{"default splash", "", func(n NameTemplate) string { return n.SplashScreen(processor.Size{Width: 2796, Height: 1290}) }, "splash/apple-splash-2796x1290.png"},
+		{"directory", "icons/{name}-{w}x{h}.{ext}", func(n NameTemplate) string { return n.Favicon(processor.Square(32)) }, "icons/favicon-32x32.png"},
+		{"directory splash", "icons/{name}-{w}x{h}.{ext}", func(n NameTemplate) string { return n.SplashScreen(processor.Size{Width: 640, Height: 1136}) }, "icons/splash/apple-splash-640x1136.png"},
+		{"directory apple 180", "icons/{name}-{w}x{h}.{ext}", func(n NameTemplate) string { return n.AppleTouchIcon(180, false) }, "icons/apple-touch-icon-180x180.png"},
+		{"directory apple 180 at root", "icons/{name}-{w}x{h}.{ext}", func(n NameTemplate) string { return n.AppleTouchIcon(180, true) }, "apple-touch-icon.png"},
+		{"size placeholder", "{name}@{size}.png", func(n NameTemplate) string { return n.WindowsTile(rect) }, "mstile@310x150.png"},
+		{"png suffix", "{name}_{w}_{h}.png", func(n NameTemplate) string { return n.MaskableIcon(192) }, "favicon-maskable_192_192.png"},
+		{"width only", "icon-{w}.png", func(n NameTemplate) string { return n.Favicon(processor.Square(32)) }, "icon-32.png"},
+		{"width only directory", "icons/{w}.png", func(n NameTemplate) string { return n.Favicon(processor.Square(32)) }, "icons/32.png"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got(tt.template); got != tt.want {
+				t.Errorf("path = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFaviconGeneratorCheckFileNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		gen     *FaviconGenerator
+		windows bool
+		splash  bool
+		wantErr bool
+	}{
+		{
+			name: "default names",
+			gen: &FaviconGenerator{
+				Targets:         SizeTargets(processor.Squares(16, 32, 180, 192, 512)...),
+				AppleTouchSizes: []int{152, 180},
+				AppleTouchRoot:  true,
+				AndroidSizes:    []int{192, 512},
+				MaskableSizes:   []int{192, 512},
+				MonochromeSizes: []int{192},
+			},
+			windows: true,
+			splash:  true,
+		},
+		{
+			name: "width-only template with a single set",
+			gen: &FaviconGenerator{
+				Targets: SizeTargets(processor.Squares(16, 32, 180)...),
+				Names:   "icon-{w}.png",
+			},
+		},
+		{
+			name: "width-only template with the root apple-touch-icon",
+			gen: &FaviconGenerator{
+				Targets:         SizeTargets(processor.Squares(16, 32, 180)...),
+				Names:           "icons/{w}.png",
+				AppleTouchSizes: []int{180},
+				AppleTouchRoot:  true,
+			},
+		},
+		{
+			name: "width-only template shared by two sets",
+			gen: &FaviconGenerator{
+				Targets:      SizeTargets(processor.Squares(16, 32, 192)...),
+				Names:        "icon-{w}.png",
+				AndroidSizes: []int{192},
+			},
+			wantErr: true,
+		},
+		{
+			name: "template without dimensions",
+			gen: &FaviconGenerator{
+				Targets: SizeTargets(processor.Squares(16, 32)...),
+				Names:   "{name}.png",
+			},
+			wantErr: true,
+		},
+		{
+			name: "target replaces the root apple-touch-icon",
+			gen: &FaviconGenerator{
+				Targets:         []Target{{Size: processor.Square(180), Name: "apple-touch-icon.png"}},
+				AppleTouchSizes: []int{180},
+				AppleTouchRoot:  true,
+			},
+		},
+		{
+			name: "target named like an apple-touch-icon of another size",
+			gen: &FaviconGenerator{
+				Targets:         []Target{{Size: processor.Square(192), Name: "apple-touch-icon.png"}},
+				AppleTouchSizes: []int{180},
+				AppleTouchRoot:  true,
+			},
+			wantErr: true,
+		},
+		{
+			name: "target replaces an android-chrome icon",
+			gen: &FaviconGenerator{
+				Targets:      []Target{{Size: processor.Square(192), Name: "android-chrome"}},
+				AndroidSizes: []int{192, 512},
+			},
+		},
+		{
+			name: "unnamed target sharing an android-chrome file",
+			gen: &FaviconGenerator{
+				Targets:      SizeTargets(processor.Square(192)),
+				Names:        "icons/{size}.png",
+				AndroidSizes: []int{192},
+			},
+			wantErr: true,
+		},
+		{
+			name: "target replaces a windows tile",
+			gen: &FaviconGenerator{
+				Targets: []Target{{Size: processor.Size{Width: 310, Height: 150}, Name: "mstile"}},
+			},
+			windows: true,
+		},
+		{
+			name: "target named like a windows tile of another size",
+			gen: &FaviconGenerator{
+				Targets: []Target{{Size: processor.Size{Width: 310, Height: 150}, Name: "mstile-150x150.png"}},
+			},
+			windows: true,
+			wantErr: true,
+		},
+		{
+			name: "windows tiles not generated",
+			gen: &FaviconGenerator{
+				Targets: []Target{{Size: processor.Square(150), Name: "mstile-310x150.png"}},
+			},
+		},
+		{
+			name: "target named like a splash screen of another size",
+			gen: &FaviconGenerator{
+				Targets: []Target{{Size: processor.Size{Width: 1136, Height: 640}, Name: "splash/./apple-splash-640x1136.png"}},
+			},
+			splash:  true,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.gen.CheckFileNames(tt.windows, tt.splash)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckFileNames() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package generator
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// SafariPinnedTabFileName is the file name of the Safari mask icon
+const SafariPinnedTabFileName = "safari-pinned-tab.svg"
+
+// paintProperties are the attributes and CSS properties that carry color
+var paintProperties = map[string]bool{
+	"fill":       true,
+	"stroke":     true,
+	"color":      true,
+	"stop-color": true,
+}
+
+// opacityProperties are dropped from mask icons, which must be solid
+var opacityProperties = map[string]bool{
+	"opacity":        true,
+	"fill-opacity":   true,
+	"stroke-opacity": true,
+}
+
+var (
+	cssPaintPattern   = regexp.MustCompile(`(?i)\b(fill|stroke|color|stop-color)\s*:\s*([^;}"]*[^;}"\s])`)
+	cssOpacityPattern = regexp.MustCompile(`(?i)\b((?:fill-|stroke-)?opacity)\s*:\s*[^;}"]*[^;}"\s]`)
+)
+
+// maskPaint flattens a paint value to black, keeping unpainted areas
+func maskPaint(value string) string {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "none", "transparent":
+		return "none"
+	default:
+		return "#000"
+	}
+}
+
+// maskIconRules turn any SVG into a single-color silhouette: every paint
+// becomes black, gradients, patterns, filters and raster images are removed.
+// Paint inside <mask> is luminance, not color, so it is left unchanged.
+var maskIconRules = svgRules{
+	keepElements: map[string]bool{"mask": true},
+	dropElements: map[string]bool{
+		"linearGradient": true,
+		"radialGradient": true,
+		"pattern":        true,
+		"filter":         true,
+		"image":          true,
+		"foreignObject":  true,
+		"script":         true,
+		"metadata":       true,
+	},
+	attr: func(a xml.Attr) (xml.Attr, bool) {
+		if a.Name.Space != "" {
+			return a, true
+		}
+		switch {
+		case paintProperties[a.Name.Local]:
+			a.Value = maskPaint(a.Value)
+		case opacityProperties[a.Name.Local], a.Name.Local == "filter":
+			return a, false
+		}
+		return a, true
+	},
+	style: func(css string) string {
+		css = cssPaintPattern.ReplaceAllStringFunc(css, func(decl string) string {
+			m := cssPaintPattern.FindStringSubmatch(decl)
+			return m[1] + ":" + maskPaint(m[2])
+		})
+		return cssOpacityPattern.ReplaceAllString(css, "$1:1")
+	},
+}
+
+// MaskIconSVG converts an SVG document into the single-color form Safari
+// expects for pinned tabs
+func MaskIconSVG(data []byte) ([]byte, error) {
+	return rewriteSVG(data, maskIconRules)
+}
+
+// GenerateSafariPinnedTab writes safari-pinned-tab.svg from an SVG source
+func (g *FaviconGenerator) GenerateSafariPinnedTab() (string, error) {
+	if !strings.EqualFold(filepath.Ext(g.SourcePath), ".svg") {
+		return "", fmt.Errorf("safari pinned tab requires an SVG source, got %s", filepath.Ext(g.SourcePath))
+	}
+
+	data, err := os.ReadFile(g.SourcePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read source: %w", err)
+	}
+
+	mask, err := MaskIconSVG(data)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(g.OutputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	path := filepath.Join(g.OutputDir, SafariPinnedTabFileName)
+	if err := os.WriteFile(path, mask, 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", SafariPinnedTabFileName, err)
+	}
+
+	return path, nil
+}
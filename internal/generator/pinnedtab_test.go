@@ -0,0 +1,123 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testColorSVG = `<?xml version="1.0" encoding="UTF-8"?>
+<!-- exported by an editor -->
+<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" viewBox="0 0 16 16">
+  <metadata>editor data</metadata>
+  <defs>
+    <linearGradient id="g"><stop offset="0" stop-color="#f00"/><stop offset="1" stop-color="#00f"/></linearGradient>
+    <style>.a { fill: #ff8800; opacity: 0.5 } .b { fill: none; stroke: red }</style>
+  </defs>
+  <rect width="16" height="16" fill="url(#g)" opacity="0.8"/>
+  <circle class="a" cx="8" cy="8" r="4" style="fill:#123456;fill-opacity:.3"/>
+  <path class="b" d="M0 0L16 16" stroke="#abcdef" fill="none"/>
+  <image xlink:href="data:image/png;base64,AAAA" width="4" height="4"/>
+  <text x="1" y="12">A &amp; B</text>
+</svg>
+`
+
+func TestMaskIconSVG(t *testing.T) {
+	got, err := MaskIconSVG([]byte(testColorSVG))
+	if err != nil {
+		t.Fatalf("MaskIconSVG() error = %v", err)
+	}
+	out := string(got)
+
+	for _, want := range []string{
+		`<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" viewBox="0 0 16 16">`,
+		`<rect width="16" height="16" fill="#000"></rect>`,
+		`style="fill:#000;fill-opacity:1"`,
+		`.a { fill:#000; opacity:1 } .b { fill:none; stroke:#000 }`,
+		`<path class="b" d="M0 0L16 16" stroke="#000" fill="none"></path>`,
+		`<text x="1" y="12">A &amp; B</text>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %s\ngot:\n%s", want, out)
+		}
+	}
+
+	for _, notWant := range []string{"linearGradient", "<image", "metadata", "exported by", "#f00", "opacity=", "url(#g)"} {
+		if strings.Contains(out, notWant) {
+			t.Errorf("output contains %s\ngot:\n%s", notWant, out)
+		}
+	}
+}
+
+func TestMaskIconSVGKeepsMasks(t *testing.T) {
+	input := `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 16 16">
+  <mask id="m"><rect width="16" height="16" fill="#fff"/><circle cx="8" cy="8" r="4" style="fill:black"/></mask>
+  <rect width="16" height="16" fill="#f00" mask="url(#m)"/>
+</svg>`
+
+	got, err := MaskIconSVG([]byte(input))
+	if err != nil {
+		t.Fatalf("MaskIconSVG() error = %v", err)
+	}
+	out := string(got)
+
+	for _, want := range []string{
+		`<mask id="m"><rect width="16" height="16" fill="#fff"></rect><circle cx="8" cy="8" r="4" style="fill:black"></circle></mask>`,
+		`<rect width="16" height="16" fill="#000" mask="url(#m)"></rect>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %s\ngot:\n%s", want, out)
+		}
+	}
+}
+
+func TestMaskIconSVGEntities(t *testing.T) {
+	got, err := MaskIconSVG([]byte(testIllustratorSVG))
+	if err != nil {
+		t.Fatalf("MaskIconSVG() error = %v", err)
+	}
+	out := string(got)
+	for _, want := range []string{`xmlns="http://www.w3.org/2000/svg"`, `<rect width="16" height="16" fill="#000"></rect>`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %s\ngot:\n%s", want, out)
+		}
+	}
+}
+
+func TestMaskIconSVGInvalid(t *testing.T) {
+	for name, input := range map[string]string{
+		"not xml":  "plain text",
+		"not svg":  "<html><body></body></html>",
+		"unclosed": "<svg><g></svg>",
+	} {
+		if _, err := MaskIconSVG([]byte(input)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestFaviconGeneratorGenerateSafariPinnedTab(t *testing.T) {
+	tmpDir := t.TempDir()
+	sourcePath := filepath.Join(tmpDir, "logo.svg")
+	if err := os.WriteFile(sourcePath, []byte(testColorSVG), 0644); err != nil {
+		t.Fatalf("failed to write source: %v", err)
+	}
+
+	gen := &FaviconGenerator{SourcePath: sourcePath, OutputDir: filepath.Join(tmpDir, "out")}
+	path, err := gen.GenerateSafariPinnedTab()
+	if err != nil {
+		t.Fatalf("GenerateSafariPinnedTab() error = %v", err)
+	}
+	if want := filepath.Join(tmpDir, "out", "safari-pinned-tab.svg"); path != want {
+		t.Errorf("path = %q, want %q", path, want)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("pinned tab not written: %v", err)
+	}
+
+	gen.SourcePath = filepath.Join(tmpDir, "logo.png")
+	if _, err := gen.GenerateSafariPinnedTab(); err == nil {
+		t.Error("expected error for PNG source")
+	}
+}
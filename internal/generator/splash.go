@@ -0,0 +1,95 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fathurrohman26/favicongen/processor"
+)
+
+// SplashScreenDir is the output subdirectory holding iOS startup images
+const SplashScreenDir = "splash"
+
+// SplashPadding keeps the logo at 40% of the shorter screen side
+var SplashPadding = processor.Padding{Value: 30, Percent: true}
+
+// SplashDevice describes an iOS screen by its portrait size in CSS pixels
+// and its device pixel ratio
+type SplashDevice struct {
+	Width      int
+	Height     int
+	PixelRatio int
+}
+
+// SplashDevices lists the iPhone and iPad screens startup images are
+// rendered for
+var SplashDevices = []SplashDevice{
+	{Width: 1024, Height: 1366, PixelRatio: 2}, // iPad Pro 12.9"
+	{Width: 834, Height: 1194, PixelRatio: 2},  // iPad Pro 11"
+	{Width: 820, Height: 1180, PixelRatio: 2},  // iPad Air 10.9"
+	{Width: 834, Height: 1112, PixelRatio: 2},  // iPad Air 10.5"
+	{Width: 810, Height: 1080, PixelRatio: 2},  // iPad 10.2"
+	{Width: 768, Height: 1024, PixelRatio: 2},  // iPad 9.7", iPad mini
+	{Width: 744, Height: 1133, PixelRatio: 2},  // iPad mini 8.3"
+	{Width: 440, Height: 956, PixelRatio: 3},   // iPhone 16 Pro Max
+	{Width: 402, Height: 874, PixelRatio: 3},   // iPhone 16 Pro
+	{Width: 430, Height: 932, PixelRatio: 3},   // iPhone 14 Pro Max, 15 Plus, 15 Pro Max, 16 Plus
+	{Width: 393, Height: 852, PixelRatio: 3},   // iPhone 14 Pro, 15, 15 Pro, 16
+	{Width: 428, Height: 926, PixelRatio: 3},   // iPhone 12 Pro Max, 13 Pro Max, 14 Plus
+	{Width: 390, Height: 844, PixelRatio: 3},   // iPhone 12, 13, 14
+	{Width: 375, Height: 812, PixelRatio: 3},   // iPhone X, XS, 11 Pro, 12 mini, 13 mini
+	{Width: 414, Height: 896, PixelRatio: 3},   // iPhone XS Max, 11 Pro Max
+	{Width: 414, Height: 896, PixelRatio: 2},   // iPhone XR, 11
+	{Width: 414, Height: 736, PixelRatio: 3},   // iPhone 6 Plus to 8 Plus
+	{Width: 375, Height: 667, PixelRatio: 2},   // iPhone 6 to 8, SE 2nd and 3rd gen
+	{Width: 320, Height: 568, PixelRatio: 2},   // iPhone 5, SE 1st gen
+}
+
+// SplashScreen is a startup image for one device orientation
+type SplashScreen struct {
+	Device    SplashDevice
+	Landscape bool
+}
+
+// SplashScreens returns a portrait and a landscape screen for every device
+// in SplashDevices
+func SplashScreens() []SplashScreen {
+	screens := make([]SplashScreen, 0, 2*len(SplashDevices))
+	for _, d := range SplashDevices {
+		screens = append(screens, SplashScreen{Device: d}, SplashScreen{Device: d, Landscape: true})
+	}
+	return screens
+}
+
+// Size returns the image size in device pixels
+func (s SplashScreen) Size() processor.Size {
+	w, h := s.Device.Width*s.Device.PixelRatio, s.Device.Height*s.Device.PixelRatio
+	if s.Landscape {
+		w, h = h, w
+	}
+	return processor.Size{Width: w, Height: h}
+}
+
+// Media returns the media query selecting the screen. iOS always reports
+// the portrait dimensions as device-width and device-height.
+func (s SplashScreen) Media() string {
+	orientation := "portrait"
+	if s.Landscape {
+		orientation = "landscape"
+	}
+	return fmt.Sprintf("(device-width: %dpx) and (device-height: %dpx) and (-webkit-device-pixel-ratio: %d) and (orientation: %s)",
+		s.Device.Width, s.Device.Height, s.Device.PixelRatio, orientation)
+}
+
+// GenerateSplashScreens renders every screen from SplashScreens into
+// SplashScreenDir with SplashOptions. iOS shows the image until the web app
+// has loaded, so callers normally center the logo on the app background
+// color.
+func (g *FaviconGenerator) GenerateSplashScreens(ctx context.Context) ([]string, error) {
+	screens := SplashScreens()
+	sizes := make([]processor.Size, 0, len(screens))
+	for _, s := range screens {
+		sizes = append(sizes, s.Size())
+	}
+	return g.renderSet(ctx, sizes, g.SplashOptions, g.Names.SplashScreen, "splash screen")
+}
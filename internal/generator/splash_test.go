@@ -0,0 +1,76 @@
+package generator
+
+import (
+	"context"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fathurrohman26/favicongen/processor"
+)
+
+func TestSplashScreen(t *testing.T) {
+	s := SplashScreen{Device: SplashDevice{Width: 430, Height: 932, PixelRatio: 3}, Landscape: true}
+
+	if got := s.Size(); got != (processor.Size{Width: 2796, Height: 1290}) {
+		t.Errorf("Size() = %v, want 2796x1290", got)
+	}
+	want := "(device-width: 430px) and (device-height: 932px) and (-webkit-device-pixel-ratio: 3) and (orientation: landscape)"
+	if got := s.Media(); got != want {
+		t.Errorf("Media() = %q, want %q", got, want)
+	}
+}
+
+func TestSplashScreensUniqueFileNames(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, s := range SplashScreens() {
+		name := DefaultNameTemplate.SplashScreen(s.Size())
+		if seen[name] {
+			t.Errorf("duplicate splash screen file %s", name)
+		}
+		seen[name] = true
+	}
+	if len(seen) != 2*len(SplashDevices) {
+		t.Errorf("got %d splash screens, want %d", len(seen), 2*len(SplashDevices))
+	}
+}
+
+func TestFaviconGeneratorGenerateSplashScreens(t *testing.T) {
+	_, sourcePath, outputDir, cleanup := createTestEnv(t)
+	defer cleanup()
+
+	opts := processor.ResizeOptions{Background: color.White, Padding: SplashPadding}
+	mockProc := newMockProcessor()
+	gen := &FaviconGenerator{
+		Processor:     mockProc,
+		SourcePath:    sourcePath,
+		OutputDir:     outputDir,
+		SplashOptions: opts,
+	}
+
+	paths, err := gen.GenerateSplashScreens(context.Background())
+	if err != nil {
+		t.Fatalf("GenerateSplashScreens() error = %v", err)
+	}
+
+	screens := SplashScreens()
+	if len(paths) != len(screens) {
+		t.Fatalf("got %d paths, want %d", len(paths), len(screens))
+	}
+	for i, s := range screens {
+		want := filepath.Join(outputDir, filepath.FromSlash(DefaultNameTemplate.SplashScreen(s.Size())))
+		if paths[i] != want {
+			t.Errorf("paths[%d] = %q, want %q", i, paths[i], want)
+		}
+		if _, err := os.Stat(want); err != nil {
+			t.Errorf("expected file %s to exist", want)
+		}
+	}
+
+	for _, call := range mockProc.resizeCalls {
+		if call.opts != opts {
+			t.Errorf("resize %v called with %+v, want splash options", call.size, call.opts)
+		}
+	}
+}
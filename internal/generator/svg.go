@@ -0,0 +1,192 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// svgRules describes how rewriteSVG transforms a document
+type svgRules struct {
+	// dropElements lists element names removed together with their children
+	dropElements map[string]bool
+
+	// keepElements lists element names whose attributes, styles and
+	// children are copied without applying attr and style
+	keepElements map[string]bool
+
+	// attr rewrites an attribute; returning false drops it
+	attr func(a xml.Attr) (xml.Attr, bool)
+
+	// style rewrites the contents of style attributes and <style> elements
+	style func(css string) string
+
+	// rootContent is inserted as the first child of the root element
+	rootContent string
+
+	// keepWhitespace preserves whitespace-only text between elements;
+	// whitespace inside <text> is always kept
+	keepWhitespace bool
+}
+
+// rewriteSVG re-serializes an SVG document according to rules. Comments are
+// always removed; prefixes are written exactly as they appear in the input.
+// Entities declared in the internal DTD subset are expanded and the output
+// is always UTF-8.
+func rewriteSVG(data []byte, rules svgRules) ([]byte, error) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	d.Entity = maps.Clone(xml.HTMLEntity)
+	d.CharsetReader = svgCharsetReader
+
+	var out bytes.Buffer
+	var stack []string
+	skipDepth := 0
+	keepDepth := 0
+	sawRoot := false
+
+	for {
+		tok, err := d.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid SVG: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if skipDepth > 0 || rules.dropElements[t.Name.Local] {
+				skipDepth++
+				continue
+			}
+			if len(stack) == 0 {
+				if t.Name.Local != "svg" {
+					return nil, fmt.Errorf("invalid SVG: root element is <%s>", t.Name.Local)
+				}
+				sawRoot = true
+			}
+			stack = append(stack, t.Name.Local)
+			if keepDepth > 0 || rules.keepElements[t.Name.Local] {
+				keepDepth++
+			}
+
+			out.WriteString("<" + qualifiedName(t.Name))
+			for _, a := range t.Attr {
+				if keepDepth > 0 {
+					out.WriteString(" " + qualifiedName(a.Name) + `="` + attrEscaper.Replace(a.Value) + `"`)
+					continue
+				}
+				if rules.style != nil && a.Name.Space == "" && a.Name.Local == "style" {
+					a.Value = rules.style(a.Value)
+				}
+				if rules.attr != nil {
+					var keep bool
+					if a, keep = rules.attr(a); !keep {
+						continue
+					}
+				}
+				out.WriteString(" " + qualifiedName(a.Name) + `="` + attrEscaper.Replace(a.Value) + `"`)
+			}
+			out.WriteString(">")
+			if len(stack) == 1 {
+				out.WriteString(rules.rootContent)
+			}
+
+		case xml.EndElement:
+			if skipDepth > 0 {
+				skipDepth--
+				continue
+			}
+			if len(stack) == 0 || stack[len(stack)-1] != t.Name.Local {
+				return nil, fmt.Errorf("invalid SVG: unexpected </%s>", qualifiedName(t.Name))
+			}
+			stack = stack[:len(stack)-1]
+			if keepDepth > 0 {
+				keepDepth--
+			}
+			out.WriteString("</" + qualifiedName(t.Name) + ">")
+
+		case xml.CharData:
+			if skipDepth > 0 || len(stack) == 0 {
+				continue
+			}
+			text := string(t)
+			if stack[len(stack)-1] == "style" && rules.style != nil && keepDepth == 0 {
+				text = rules.style(text)
+			}
+			if !rules.keepWhitespace && strings.TrimSpace(text) == "" && !slices.Contains(stack, "text") {
+				continue
+			}
+			out.WriteString(textEscaper.Replace(text))
+
+		case xml.ProcInst:
+			if t.Target == "xml" && !sawRoot {
+				inst := xmlEncodingPattern.ReplaceAllString(string(t.Inst), `${1}"UTF-8"`)
+				out.WriteString("<?xml " + inst + "?>")
+			}
+
+		case xml.Directive:
+			if !sawRoot {
+				for _, m := range entityDeclPattern.FindAllStringSubmatch(string(t), -1) {
+					d.Entity[m[1]] = m[2] + m[3]
+				}
+				out.WriteString("<!" + string(t) + ">")
+			}
+		}
+	}
+
+	if !sawRoot {
+		return nil, fmt.Errorf("invalid SVG: no <svg> element")
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("invalid SVG: unclosed <%s>", stack[len(stack)-1])
+	}
+	return out.Bytes(), nil
+}
+
+var (
+	// entityDeclPattern matches internal general entity declarations such
+	// as <!ENTITY ns_svg "http://www.w3.org/2000/svg"> in a DOCTYPE
+	entityDeclPattern = regexp.MustCompile(`<!ENTITY\s+([A-Za-z_:][\w.:-]*)\s+(?:"([^"]*)"|'([^']*)')\s*>`)
+
+	// xmlEncodingPattern matches the encoding of an XML declaration
+	xmlEncodingPattern = regexp.MustCompile(`(encoding\s*=\s*)("[^"]*"|'[^']*')`)
+)
+
+// svgCharsetReader converts the single-byte encodings SVG editors declare
+// besides UTF-8 into UTF-8
+func svgCharsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "iso_8859-1", "latin1", "l1", "us-ascii", "ascii":
+		data, err := io.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		var b strings.Builder
+		for _, c := range data {
+			b.WriteRune(rune(c))
+		}
+		return strings.NewReader(b.String()), nil
+	default:
+		return nil, fmt.Errorf("unsupported encoding %q", charset)
+	}
+}
+
+var (
+	textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	attrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", `"`, "&quot;", "\n", "&#10;", "\t", "&#9;")
+)
+
+// qualifiedName formats a raw token name with its original prefix
+func qualifiedName(n xml.Name) string {
+	if n.Space == "" {
+		return n.Local
+	}
+	return n.Space + ":" + n.Local
+}
@@ -0,0 +1,111 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// SVGFaviconFileName is the file name of the scalable favicon
+const SVGFaviconFileName = "favicon.svg"
+
+// ColorReplacement swaps one paint color for another
+type ColorReplacement struct {
+	From string
+	To   string
+}
+
+// SVGDarkColors describes how favicon.svg is recolored when the browser
+// prefers a dark color scheme
+type SVGDarkColors struct {
+	// Fill is the default fill of shapes without their own color
+	Fill string
+
+	// Replace swaps fill and stroke colors set by attributes or inline styles
+	Replace []ColorReplacement
+}
+
+// IsZero reports whether no dark mode colors are configured
+func (d SVGDarkColors) IsZero() bool {
+	return d.Fill == "" && len(d.Replace) == 0
+}
+
+// css returns the style block implementing the dark mode colors
+func (d SVGDarkColors) css() string {
+	var rules []string
+	if d.Fill != "" {
+		rules = append(rules, fmt.Sprintf("svg{fill:%s}", d.Fill))
+	}
+	for _, r := range d.Replace {
+		for _, prop := range []string{"fill", "stroke"} {
+			rules = append(rules, fmt.Sprintf(`[%[1]s="%[2]s" i],[style*="%[1]s:%[2]s" i]{%[1]s:%[3]s!important}`, prop, r.From, r.To))
+		}
+	}
+	return "<style>@media (prefers-color-scheme:dark){" + strings.Join(rules, "") + "}</style>"
+}
+
+// cssColorPattern accepts hex colors, color names and color functions while
+// rejecting anything that could break out of the style block
+var cssColorPattern = regexp.MustCompile(`^(#[0-9a-fA-F]{3,8}|[a-zA-Z]+|[a-zA-Z]+\([0-9.,%\s]+\))$`)
+
+// ValidateSVGColor checks that a color can be embedded in favicon.svg
+func ValidateSVGColor(c string) error {
+	if !cssColorPattern.MatchString(c) {
+		return fmt.Errorf("invalid SVG color %q", c)
+	}
+	return nil
+}
+
+// normalizePaint rewrites fill and stroke declarations as prop:value so the
+// dark mode selectors can match inline styles
+func normalizePaint(css string) string {
+	return cssPaintPattern.ReplaceAllStringFunc(css, func(decl string) string {
+		m := cssPaintPattern.FindStringSubmatch(decl)
+		return strings.ToLower(m[1]) + ":" + strings.TrimSpace(m[2])
+	})
+}
+
+// FaviconSVG minifies an SVG document for use as favicon.svg, removing
+// comments, metadata and formatting whitespace, and injects the dark mode
+// colors when configured
+func FaviconSVG(data []byte, dark SVGDarkColors) ([]byte, error) {
+	rules := svgRules{
+		dropElements: map[string]bool{"metadata": true},
+		style:        normalizePaint,
+	}
+	if !dark.IsZero() {
+		rules.rootContent = dark.css()
+	}
+	return rewriteSVG(data, rules)
+}
+
+// GenerateSVGFavicon writes favicon.svg from an SVG source, applying
+// SVGDarkColors
+func (g *FaviconGenerator) GenerateSVGFavicon() (string, error) {
+	if !strings.EqualFold(filepath.Ext(g.SourcePath), ".svg") {
+		return "", fmt.Errorf("SVG favicon requires an SVG source, got %s", filepath.Ext(g.SourcePath))
+	}
+
+	data, err := os.ReadFile(g.SourcePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read source: %w", err)
+	}
+
+	svg, err := FaviconSVG(data, g.SVGDarkColors)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(g.OutputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	path := filepath.Join(g.OutputDir, SVGFaviconFileName)
+	if err := os.WriteFile(path, svg, 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", SVGFaviconFileName, err)
+	}
+
+	return path, nil
+}
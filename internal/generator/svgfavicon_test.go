@@ -0,0 +1,136 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFaviconSVG(t *testing.T) {
+	input := `<?xml version="1.0"?>
+<!-- logo -->
+<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 16 16">
+  <metadata>editor data</metadata>
+  <rect width="16" height="16" fill="#000000"/>
+  <circle cx="8" cy="8" r="4" style="fill: #FF0000; stroke-width: 2"/>
+</svg>
+`
+
+	t.Run("minifies", func(t *testing.T) {
+		got, err := FaviconSVG([]byte(input), SVGDarkColors{})
+		if err != nil {
+			t.Fatalf("FaviconSVG() error = %v", err)
+		}
+		want := `<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 16 16">` +
+			`<rect width="16" height="16" fill="#000000"></rect>` +
+			`<circle cx="8" cy="8" r="4" style="fill:#FF0000; stroke-width: 2"></circle></svg>`
+		if string(got) != want {
+			t.Errorf("FaviconSVG() =\n%s\nwant\n%s", got, want)
+		}
+	})
+
+	t.Run("injects dark mode colors", func(t *testing.T) {
+		dark := SVGDarkColors{
+			Fill:    "#eeeeee",
+			Replace: []ColorReplacement{{From: "#ff0000", To: "#ff8080"}},
+		}
+		got, err := FaviconSVG([]byte(input), dark)
+		if err != nil {
+			t.Fatalf("FaviconSVG() error = %v", err)
+		}
+		want := `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 16 16"><style>@media (prefers-color-scheme:dark){` +
+			`svg{fill:#eeeeee}` +
+			`[fill="#ff0000" i],[style*="fill:#ff0000" i]{fill:#ff8080!important}` +
+			`[stroke="#ff0000" i],[style*="stroke:#ff0000" i]{stroke:#ff8080!important}` +
+			`}</style><rect`
+		if !strings.Contains(string(got), want) {
+			t.Errorf("FaviconSVG() =\n%s\nwant to contain\n%s", got, want)
+		}
+	})
+}
+
+// testIllustratorSVG mimics an Adobe Illustrator export, which declares its
+// namespaces as internal DTD entities
+const testIllustratorSVG = `<?xml version="1.0" encoding="utf-8"?>
+<!DOCTYPE svg PUBLIC "-//W3C//DTD SVG 1.1//EN" "http://www.w3.org/Graphics/SVG/1.1/DTD/svg11.dtd" [
+	<!ENTITY ns_svg "http://www.w3.org/2000/svg">
+	<!ENTITY ns_xlink 'http://www.w3.org/1999/xlink'>
+]>
+<svg version="1.1" xmlns="&ns_svg;" xmlns:xlink="&ns_xlink;" viewBox="0 0 16 16">
+  <rect width="16" height="16" fill="#FF0000"/>
+</svg>
+`
+
+func TestFaviconSVGEntities(t *testing.T) {
+	got, err := FaviconSVG([]byte(testIllustratorSVG), SVGDarkColors{})
+	if err != nil {
+		t.Fatalf("FaviconSVG() error = %v", err)
+	}
+	want := `<svg version="1.1" xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" viewBox="0 0 16 16">`
+	if !strings.Contains(string(got), want) {
+		t.Errorf("FaviconSVG() =\n%s\nwant to contain\n%s", got, want)
+	}
+}
+
+func TestFaviconSVGLatin1(t *testing.T) {
+	input := "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?>\n" +
+		"<svg xmlns=\"http://www.w3.org/2000/svg\" viewBox=\"0 0 16 16\"><title>Caf\xe9</title></svg>"
+
+	got, err := FaviconSVG([]byte(input), SVGDarkColors{})
+	if err != nil {
+		t.Fatalf("FaviconSVG() error = %v", err)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?><svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 16 16"><title>Café</title></svg>`
+	if string(got) != want {
+		t.Errorf("FaviconSVG() =\n%s\nwant\n%s", got, want)
+	}
+
+	input = strings.Replace(input, "ISO-8859-1", "Shift_JIS", 1)
+	if _, err := FaviconSVG([]byte(input), SVGDarkColors{}); err == nil {
+		t.Error("expected error for unsupported encoding")
+	}
+}
+
+func TestValidateSVGColor(t *testing.T) {
+	for _, c := range []string{"#fff", "#12345678", "white", "rgb(10, 20, 30)"} {
+		if err := ValidateSVGColor(c); err != nil {
+			t.Errorf("ValidateSVGColor(%q) error = %v", c, err)
+		}
+	}
+	for _, c := range []string{"", "#fff}svg{", `red"`, "url(#x)"} {
+		if err := ValidateSVGColor(c); err == nil {
+			t.Errorf("ValidateSVGColor(%q) should fail", c)
+		}
+	}
+}
+
+func TestFaviconGeneratorGenerateSVGFavicon(t *testing.T) {
+	tmpDir := t.TempDir()
+	sourcePath := filepath.Join(tmpDir, "logo.svg")
+	if err := os.WriteFile(sourcePath, []byte(testColorSVG), 0644); err != nil {
+		t.Fatalf("failed to write source: %v", err)
+	}
+
+	gen := &FaviconGenerator{SourcePath: sourcePath, OutputDir: tmpDir}
+	path, err := gen.GenerateSVGFavicon()
+	if err != nil {
+		t.Fatalf("GenerateSVGFavicon() error = %v", err)
+	}
+	if path != filepath.Join(tmpDir, "favicon.svg") {
+		t.Errorf("path = %q", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read favicon.svg: %v", err)
+	}
+	if strings.Contains(string(data), "exported by") || !strings.Contains(string(data), "linearGradient") {
+		t.Errorf("favicon.svg should drop comments but keep artwork:\n%s", data)
+	}
+
+	gen.SourcePath = filepath.Join(tmpDir, "logo.png")
+	if _, err := gen.GenerateSVGFavicon(); err == nil {
+		t.Error("expected error for PNG source")
+	}
+}
@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"image/color"
+	"path"
+
+	"github.com/fathurrohman26/favicongen/processor"
+)
+
+// Target is one PNG favicon rendered by Generate
+type Target struct {
+	Size processor.Size
+
+	// Name overrides the file name. A name with an extension is the path of
+	// the file relative to the output directory; a bare name replaces
+	// {name} in the naming template. Empty means FaviconName.
+	Name string
+
+	// Padding and Background, when non-nil, replace the corresponding
+	// resize options of the size. A nil color means transparent.
+	Padding    *processor.Padding
+	Background *color.Color
+}
+
+// SizeTargets returns an unnamed target without overrides for every size
+func SizeTargets(sizes ...processor.Size) []Target {
+	targets := make([]Target, 0, len(sizes))
+	for _, size := range sizes {
+		targets = append(targets, Target{Size: size})
+	}
+	return targets
+}
+
+// FileName returns the path of the target relative to the output directory
+func (t Target) FileName(names NameTemplate) string {
+	switch {
+	case t.Name == "":
+		return names.Favicon(t.Size)
+	case path.Ext(t.Name) != "":
+		return path.Clean(t.Name)
+	default:
+		return names.Path(t.Name, t.Size)
+	}
+}
+
+// writtenByTarget reports whether a named target renders the file name at
+// size, replacing the entry of a built-in image set
+func (g *FaviconGenerator) writtenByTarget(name string, size processor.Size) bool {
+	name = path.Clean(name)
+	for _, t := range g.Targets {
+		if t.Name != "" && t.Size == size && t.FileName(g.Names) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the size, followed by the name when one is set
+func (t Target) String() string {
+	if t.Name == "" {
+		return t.Size.String()
+	}
+	return t.Size.String() + ":" + t.Name
+}
+
+// options applies the target overrides to base
+func (t Target) options(base processor.ResizeOptions) processor.ResizeOptions {
+	if t.Padding != nil {
+		base.Padding = *t.Padding
+	}
+	if t.Background != nil {
+		base.Background = *t.Background
+	}
+	return base
+}
@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"context"
+	"image/color"
+	"path/filepath"
+	"testing"
+
+	"github.com/fathurrohman26/favicongen/processor"
+)
+
+func TestTargetFileName(t *testing.T) {
+	tests := []struct {
+		target Target
+		names  NameTemplate
+		want   string
+	}{
+		{Target{Size: processor.Square(32)}, "", "favicon-32x32.png"},
+		{Target{Size: processor.Square(180), Name: "apple-touch-icon.png"}, "", "apple-touch-icon.png"},
+		{Target{Size: processor.Square(192), Name: "android-chrome"}, "", "android-chrome-192x192.png"},
+		{Target{Size: processor.Square(192), Name: "android-chrome"}, "icons/{name}-{w}x{h}.{ext}", "icons/android-chrome-192x192.png"},
+		{Target{Size: processor.Square(180), Name: "img/./touch.png"}, "icons/{name}-{w}x{h}.{ext}", "img/touch.png"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target.String(), func(t *testing.T) {
+			if got := tt.target.FileName(tt.names); got != tt.want {
+				t.Errorf("FileName(%q) = %q, want %q", tt.names, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTargetString(t *testing.T) {
+	if got := (Target{Size: processor.Square(16)}).String(); got != "16x16" {
+		t.Errorf("String() = %q, want 16x16", got)
+	}
+	if got := (Target{Size: processor.Square(192), Name: "android-chrome"}).String(); got != "192x192:android-chrome" {
+		t.Errorf("String() = %q, want 192x192:android-chrome", got)
+	}
+}
+
+func TestFaviconGeneratorGenerateTargets(t *testing.T) {
+	_, sourcePath, outputDir, cleanup := createTestEnv(t)
+	defer cleanup()
+
+	padding := processor.Padding{Value: 10, Percent: true}
+	var transparent color.Color
+	white := color.Color(color.White)
+	base := processor.ResizeOptions{Upscale: true, Background: color.Black}
+
+	mockProc := newMockProcessor()
+	gen := &FaviconGenerator{
+		Processor:     mockProc,
+		SourcePath:    sourcePath,
+		OutputDir:     outputDir,
+		ResizeOptions: base,
+		Targets: []Target{
+			{Size: processor.Square(16)},
+			{Size: processor.Square(180), Name: "apple-touch-icon.png", Padding: &padding, Background: &white},
+			{Size: processor.Square(192), Name: "android-chrome", Background: &transparent},
+		},
+	}
+
+	result, err := gen.Generate(context.Background())
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	wantFiles := []string{
+		filepath.Join(outputDir, "favicon-16x16.png"),
+		filepath.Join(outputDir, "apple-touch-icon.png"),
+		filepath.Join(outputDir, "android-chrome-192x192.png"),
+	}
+	for i, want := range wantFiles {
+		if result.GeneratedFiles[i] != want {
+			t.Errorf("GeneratedFiles[%d] = %q, want %q", i, result.GeneratedFiles[i], want)
+		}
+	}
+
+	apple := base
+	apple.Padding = padding
+	apple.Background = color.White
+	android := base
+	android.Background = nil
+	wantOpts := map[processor.Size]processor.ResizeOptions{
+		processor.Square(16):  base,
+		processor.Square(180): apple,
+		processor.Square(192): android,
+	}
+	for _, call := range mockProc.resizeCalls {
+		if call.opts != wantOpts[call.size] {
+			t.Errorf("resize %v called with %+v, want %+v", call.size, call.opts, wantOpts[call.size])
+		}
+	}
+}
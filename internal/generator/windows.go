@@ -0,0 +1,88 @@
+package generator
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fathurrohman26/favicongen/processor"
+)
+
+// BrowserConfigFileName is the file name of the Windows tile configuration
+const BrowserConfigFileName = "browserconfig.xml"
+
+// WindowsTile describes a Microsoft tile image
+type WindowsTile struct {
+	// Element is the browserconfig.xml element referencing the image
+	Element string
+	Size    processor.Size
+}
+
+// WindowsTiles lists the tiles Windows 8 and 10 pin to the start screen
+var WindowsTiles = []WindowsTile{
+	{Element: "square70x70logo", Size: processor.Square(70)},
+	{Element: "square150x150logo", Size: processor.Square(150)},
+	{Element: "square310x310logo", Size: processor.Square(310)},
+	{Element: "wide310x150logo", Size: processor.Size{Width: 310, Height: 150}},
+}
+
+// GenerateWindowsTiles renders every tile in WindowsTiles with
+// WindowsTileOptions. Tiles are shown on the tile color, so the options
+// normally keep a transparent background.
+func (g *FaviconGenerator) GenerateWindowsTiles(ctx context.Context) ([]string, error) {
+	sizes := make([]processor.Size, 0, len(WindowsTiles))
+	for _, tile := range WindowsTiles {
+		sizes = append(sizes, tile.Size)
+	}
+	return g.renderSet(ctx, sizes, g.WindowsTileOptions, g.Names.WindowsTile, "windows tile")
+}
+
+// BrowserConfigConfig contains configuration for browserconfig.xml
+type BrowserConfigConfig struct {
+	TileColor string
+
+	// Names must match the template the tiles were generated with
+	Names NameTemplate
+}
+
+// browserConfig mirrors the browserconfig.xml schema
+type browserConfig struct {
+	XMLName xml.Name `xml:"browserconfig"`
+	Tile    struct {
+		Logos     []browserConfigLogo
+		TileColor string `xml:"TileColor,omitempty"`
+	} `xml:"msapplication>tile"`
+}
+
+type browserConfigLogo struct {
+	XMLName xml.Name
+	Src     string `xml:"src,attr"`
+}
+
+// GenerateBrowserConfig creates a browserconfig.xml file referencing every
+// tile in WindowsTiles
+func GenerateBrowserConfig(config *BrowserConfigConfig, outputDir string) (string, error) {
+	var bc browserConfig
+	for _, tile := range WindowsTiles {
+		bc.Tile.Logos = append(bc.Tile.Logos, browserConfigLogo{
+			XMLName: xml.Name{Local: tile.Element},
+			Src:     "/" + config.Names.WindowsTile(tile.Size),
+		})
+	}
+	bc.Tile.TileColor = config.TileColor
+
+	data, err := xml.MarshalIndent(bc, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal browserconfig: %w", err)
+	}
+	data = append([]byte(xml.Header), append(data, '\n')...)
+
+	path := filepath.Join(outputDir, BrowserConfigFileName)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write browserconfig file: %w", err)
+	}
+
+	return path, nil
+}
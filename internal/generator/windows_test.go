@@ -0,0 +1,126 @@
+package generator
+
+import (
+	"context"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fathurrohman26/favicongen/processor"
+)
+
+func TestFaviconGeneratorGenerateWindowsTiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	sourcePath := filepath.Join(tmpDir, "source.png")
+	writeTestPNG(t, sourcePath, 512)
+
+	gen := &FaviconGenerator{
+		Processor:  &processor.NativeProcessor{},
+		SourcePath: sourcePath,
+		OutputDir:  filepath.Join(tmpDir, "out"),
+	}
+
+	paths, err := gen.GenerateWindowsTiles(context.Background())
+	if err != nil {
+		t.Fatalf("GenerateWindowsTiles() error = %v", err)
+	}
+	if len(paths) != len(WindowsTiles) {
+		t.Fatalf("got %d tiles, want %d", len(paths), len(WindowsTiles))
+	}
+
+	for i, tile := range WindowsTiles {
+		if want := filepath.Join(gen.OutputDir, DefaultNameTemplate.WindowsTile(tile.Size)); paths[i] != want {
+			t.Errorf("paths[%d] = %q, want %q", i, paths[i], want)
+		}
+
+		f, err := os.Open(paths[i])
+		if err != nil {
+			t.Fatalf("failed to open tile: %v", err)
+		}
+		img, err := png.Decode(f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("failed to decode tile: %v", err)
+		}
+
+		if got := img.Bounds().Size(); got.X != tile.Size.Width || got.Y != tile.Size.Height {
+			t.Errorf("%s size = %dx%d, want %v", DefaultNameTemplate.WindowsTile(tile.Size), got.X, got.Y, tile.Size)
+		}
+	}
+
+	// The wide tile keeps the source square and centered
+	f, err := os.Open(filepath.Join(gen.OutputDir, "mstile-310x150.png"))
+	if err != nil {
+		t.Fatalf("failed to open wide tile: %v", err)
+	}
+	defer f.Close()
+	wide, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("failed to decode wide tile: %v", err)
+	}
+	if _, _, _, a := wide.At(10, 75).RGBA(); a != 0 {
+		t.Error("wide tile edge should be transparent")
+	}
+	if _, _, _, a := wide.At(155, 75).RGBA(); a == 0 {
+		t.Error("wide tile center should show the source")
+	}
+}
+
+func TestGenerateBrowserConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	path, err := GenerateBrowserConfig(&BrowserConfigConfig{TileColor: "#da532c"}, tmpDir)
+	if err != nil {
+		t.Fatalf("GenerateBrowserConfig() error = %v", err)
+	}
+	if path != filepath.Join(tmpDir, "browserconfig.xml") {
+		t.Errorf("path = %q", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read browserconfig: %v", err)
+	}
+
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<browserconfig>
+  <msapplication>
+    <tile>
+      <square70x70logo src="/mstile-70x70.png"></square70x70logo>
+      <square150x150logo src="/mstile-150x150.png"></square150x150logo>
+      <square310x310logo src="/mstile-310x310.png"></square310x310logo>
+      <wide310x150logo src="/mstile-310x150.png"></wide310x150logo>
+      <TileColor>#da532c</TileColor>
+    </tile>
+  </msapplication>
+</browserconfig>
+`
+	if string(data) != want {
+		t.Errorf("browserconfig.xml =\n%s\nwant\n%s", data, want)
+	}
+}
+
+func TestGenerateBrowserConfigNameTemplate(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	path, err := GenerateBrowserConfig(&BrowserConfigConfig{TileColor: "#da532c", Names: "icons/{name}-{w}x{h}.{ext}"}, tmpDir)
+	if err != nil {
+		t.Fatalf("GenerateBrowserConfig() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read browserconfig: %v", err)
+	}
+	if !strings.Contains(string(data), `<wide310x150logo src="/icons/mstile-310x150.png">`) {
+		t.Errorf("browserconfig.xml does not use the name template:\n%s", data)
+	}
+}
+
+func TestGenerateBrowserConfigInvalidDir(t *testing.T) {
+	if _, err := GenerateBrowserConfig(&BrowserConfigConfig{}, "/nonexistent/path/that/should/not/exist"); err == nil {
+		t.Error("expected error for invalid output directory")
+	}
+}
@@ -0,0 +1,103 @@
+package processor
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Capabilities describes what a processor backend can handle
+type Capabilities struct {
+	// InputFormats lists readable source formats as lowercase extensions
+	// without the leading dot (e.g. "png", "svg")
+	InputFormats []string
+
+	// OutputFormats lists writable formats (e.g. "png", "ico")
+	OutputFormats []string
+
+	// ICO reports whether ConvertToICO is supported
+	ICO bool
+
+	// Upscale reports whether sources can be enlarged beyond their size
+	Upscale bool
+}
+
+// Requirements describes what a generation job needs from a processor
+type Requirements struct {
+	InputFormat   string
+	OutputFormats []string
+	ICO           bool
+	Upscale       bool
+}
+
+// SupportsInput reports whether format can be read
+func (c Capabilities) SupportsInput(format string) bool {
+	return slices.Contains(c.InputFormats, strings.ToLower(format))
+}
+
+// SupportsOutput reports whether format can be written
+func (c Capabilities) SupportsOutput(format string) bool {
+	return slices.Contains(c.OutputFormats, strings.ToLower(format))
+}
+
+// Check returns an error describing the first requirement that is not met
+func (c Capabilities) Check(req Requirements) error {
+	if req.InputFormat != "" && !c.SupportsInput(req.InputFormat) {
+		return fmt.Errorf("cannot read %s sources", req.InputFormat)
+	}
+	for _, format := range req.OutputFormats {
+		if !c.SupportsOutput(format) {
+			return fmt.Errorf("cannot write %s files", format)
+		}
+	}
+	if req.ICO && !c.ICO {
+		return fmt.Errorf("cannot create ICO files")
+	}
+	if req.Upscale && !c.Upscale {
+		return fmt.Errorf("cannot upscale images")
+	}
+	return nil
+}
+
+// SourceFormat returns the format of a source path as used in Requirements.
+// ICO sources are decoded in-process into PNG before reaching a processor.
+func SourceFormat(path string) string {
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	if format == "ico" {
+		return "png"
+	}
+	return format
+}
+
+// SourceDimensions returns the pixel size of a raster source (PNG, or the
+// largest image of an ICO file). Vector sources report ok == false.
+func SourceDimensions(path string) (width, height int, ok bool) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".png":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return 0, 0, false
+		}
+		cfg, err := png.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return 0, 0, false
+		}
+		return cfg.Width, cfg.Height, true
+	case ".ico":
+		entries, err := ReadICO(path)
+		if err != nil {
+			return 0, 0, false
+		}
+		entry, err := LargestICOEntry(entries)
+		if err != nil {
+			return 0, 0, false
+		}
+		return entry.Width, entry.Height, true
+	default:
+		return 0, 0, false
+	}
+}
@@ -0,0 +1,144 @@
+package processor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCapabilitiesCheck(t *testing.T) {
+	caps := Capabilities{
+		InputFormats:  []string{"png", "svg"},
+		OutputFormats: []string{"png"},
+		ICO:           false,
+		Upscale:       false,
+	}
+
+	tests := []struct {
+		name    string
+		req     Requirements
+		wantErr string
+	}{
+		{name: "empty requirements", req: Requirements{}},
+		{name: "supported input", req: Requirements{InputFormat: "SVG", OutputFormats: []string{"png"}}},
+		{name: "unsupported input", req: Requirements{InputFormat: "webp"}, wantErr: "cannot read webp"},
+		{name: "unsupported output", req: Requirements{OutputFormats: []string{"png", "webp"}}, wantErr: "cannot write webp"},
+		{name: "ICO required", req: Requirements{ICO: true}, wantErr: "cannot create ICO"},
+		{name: "upscale required", req: Requirements{Upscale: true}, wantErr: "cannot upscale"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := caps.Check(tt.req)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Check() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Check() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuiltinCapabilities(t *testing.T) {
+	native := (&NativeProcessor{}).Capabilities()
+	if !native.SupportsInput("png") || native.SupportsInput("svg") {
+		t.Errorf("native inputs = %v, want png only", native.InputFormats)
+	}
+	if !native.ICO || !native.Upscale {
+		t.Error("native should support ICO and upscaling")
+	}
+
+	vips := (&VipsProcessor{}).Capabilities()
+	if !vips.ICO {
+		t.Error("vips should support ICO through the built-in encoder")
+	}
+
+	magick := (&ImageMagickProcessor{}).Capabilities()
+	if !magick.SupportsOutput("ico") || !magick.Upscale {
+		t.Error("imagemagick should support ICO output and upscaling")
+	}
+}
+
+func TestFormatListCanRead(t *testing.T) {
+	list := `   Format  Mode  Description
+-------------------------------------------------------------------------------
+      PNG* PNG       rw-   Portable Network Graphics
+      SVG  SVG       rw+   Scalable Vector Graphics (RSVG 2.54.0)
+     WEBP  WEBP      -w-   WebP Image Format
+`
+
+	tests := []struct {
+		format string
+		want   bool
+	}{
+		{"PNG", true},
+		{"SVG", true},
+		{"WEBP", false},
+		{"HEIC", false},
+	}
+
+	for _, tt := range tests {
+		if got := formatListCanRead(list, tt.format); got != tt.want {
+			t.Errorf("formatListCanRead(%q) = %v, want %v", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestSourceFormat(t *testing.T) {
+	tests := map[string]string{
+		"logo.svg":         "svg",
+		"logo.PNG":         "png",
+		"legacy/icon.ico":  "png",
+		"no-extension":     "",
+		"path/to/logo.jpg": "jpg",
+	}
+
+	for path, want := range tests {
+		if got := SourceFormat(path); got != want {
+			t.Errorf("SourceFormat(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestSourceDimensions(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	pngPath := filepath.Join(tmpDir, "logo.png")
+	writeTestPNG(t, pngPath, 120, 80)
+
+	icoPath := filepath.Join(tmpDir, "favicon.ico")
+	entryPath := filepath.Join(tmpDir, "entry.png")
+	writeTestPNG(t, entryPath, 48, 48)
+	if err := WriteICO([]string{entryPath}, icoPath, ICOEncodingPNG); err != nil {
+		t.Fatalf("failed to write ICO: %v", err)
+	}
+
+	svgPath := filepath.Join(tmpDir, "logo.svg")
+	if err := os.WriteFile(svgPath, []byte("<svg/>"), 0644); err != nil {
+		t.Fatalf("failed to write SVG: %v", err)
+	}
+
+	tests := []struct {
+		path          string
+		width, height int
+		ok            bool
+	}{
+		{pngPath, 120, 80, true},
+		{icoPath, 48, 48, true},
+		{svgPath, 0, 0, false},
+		{filepath.Join(tmpDir, "missing.png"), 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		w, h, ok := SourceDimensions(tt.path)
+		if w != tt.width || h != tt.height || ok != tt.ok {
+			t.Errorf("SourceDimensions(%q) = %d, %d, %v, want %d, %d, %v",
+				filepath.Base(tt.path), w, h, ok, tt.width, tt.height, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package processor
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+// ParseColor parses a hex color (#rgb, #rrggbb or #rrggbbaa) or
+// "transparent". Transparent is returned as a nil color.
+func ParseColor(s string) (color.Color, error) {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "transparent") || strings.EqualFold(s, "none") {
+		return nil, nil
+	}
+
+	hex, ok := strings.CutPrefix(s, "#")
+	if !ok {
+		return nil, fmt.Errorf("invalid color %q (expected #rrggbb or transparent)", s)
+	}
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	if len(hex) != 8 {
+		return nil, fmt.Errorf("invalid color %q (expected #rrggbb or transparent)", s)
+	}
+
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid color %q (expected #rrggbb or transparent)", s)
+	}
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
+
+// hexColor formats a color as #rrggbbaa for external tools
+func hexColor(c color.Color) string {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return fmt.Sprintf("#%02x%02x%02x%02x", n.R, n.G, n.B, n.A)
+}
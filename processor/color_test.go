@@ -0,0 +1,39 @@
+package processor
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    color.Color
+		wantErr bool
+	}{
+		{in: "transparent", want: nil},
+		{in: "#fff", want: color.NRGBA{R: 255, G: 255, B: 255, A: 255}},
+		{in: "#1a2b3c", want: color.NRGBA{R: 0x1a, G: 0x2b, B: 0x3c, A: 255}},
+		{in: "#1a2b3c80", want: color.NRGBA{R: 0x1a, G: 0x2b, B: 0x3c, A: 0x80}},
+		{in: "white", wantErr: true},
+		{in: "#12345", wantErr: true},
+		{in: "#gggggg", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseColor(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseColor(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseColor(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestHexColor(t *testing.T) {
+	if got := hexColor(color.NRGBA{R: 0x1a, G: 0x2b, B: 0x3c, A: 0xff}); got != "#1a2b3cff" {
+		t.Errorf("hexColor() = %q, want #1a2b3cff", got)
+	}
+}
@@ -0,0 +1,447 @@
+package processor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+)
+
+const (
+	// icoMaxDimension is the largest width or height an ICO entry can describe
+	icoMaxDimension = 256
+
+	// legacyBMPMaxSize is the largest entry stored as BMP in legacy mode
+	legacyBMPMaxSize = 48
+
+	icoHeaderSize    = 6
+	icoEntrySize     = 16
+	bmpInfoHeaderLen = 40
+
+	pngSignature = "\x89PNG\r\n\x1a\n"
+)
+
+// ICOFormat identifies how an image payload is stored inside an ICO file
+type ICOFormat string
+
+const (
+	// ICOFormatPNG stores the entry as an embedded PNG stream
+	ICOFormatPNG ICOFormat = "png"
+
+	// ICOFormatBMP stores the entry as a 32-bit DIB with an AND mask
+	ICOFormatBMP ICOFormat = "bmp"
+)
+
+// ICOEncoding selects how each entry of a generated ICO file is encoded
+type ICOEncoding string
+
+const (
+	// ICOEncodingPNG embeds every entry as PNG
+	ICOEncodingPNG ICOEncoding = "png"
+
+	// ICOEncodingBMP stores every entry as a 32-bit BMP
+	ICOEncodingBMP ICOEncoding = "bmp"
+
+	// ICOEncodingLegacy stores entries up to 48px as BMP and larger ones as PNG
+	ICOEncodingLegacy ICOEncoding = "legacy"
+)
+
+// ParseICOEncoding validates an ICO encoding name
+func ParseICOEncoding(s string) (ICOEncoding, error) {
+	switch enc := ICOEncoding(s); enc {
+	case ICOEncodingPNG, ICOEncodingBMP, ICOEncodingLegacy:
+		return enc, nil
+	default:
+		return "", fmt.Errorf("unknown ICO encoding %q (expected png, bmp or legacy)", s)
+	}
+}
+
+// ICOEntry is a single image stored in an ICO file
+type ICOEntry struct {
+	Width    int
+	Height   int
+	BitCount int
+	Format   ICOFormat
+	Data     []byte
+}
+
+// icoDir mirrors the ICONDIR header of an ICO file
+type icoDir struct {
+	Reserved uint16
+	Type     uint16
+	Count    uint16
+}
+
+// icoDirEntry mirrors the ICONDIRENTRY structure of an ICO file
+type icoDirEntry struct {
+	Width       uint8
+	Height      uint8
+	ColorCount  uint8
+	Reserved    uint8
+	Planes      uint16
+	BitCount    uint16
+	BytesInRes  uint32
+	ImageOffset uint32
+}
+
+// NewPNGEntry creates an ICO entry that embeds the given PNG data as-is
+func NewPNGEntry(data []byte) (ICOEntry, error) {
+	cfg, err := png.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return ICOEntry{}, fmt.Errorf("invalid PNG data: %w", err)
+	}
+
+	return ICOEntry{
+		Width:    cfg.Width,
+		Height:   cfg.Height,
+		BitCount: 32,
+		Format:   ICOFormatPNG,
+		Data:     data,
+	}, nil
+}
+
+// NewBMPEntry creates an ICO entry storing img as a 32-bit BMP (DIB) with an
+// AND mask, as understood by legacy Windows and embedded browser icon loaders
+func NewBMPEntry(img image.Image) (ICOEntry, error) {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= 0 || height <= 0 {
+		return ICOEntry{}, fmt.Errorf("invalid image size %dx%d", width, height)
+	}
+
+	// XOR bitmap rows are 4 bytes per pixel, AND mask rows are 1 bit per
+	// pixel; both are padded to 32 bits and stored bottom-up
+	xorStride := width * 4
+	andStride := (width + 31) / 32 * 4
+	xorSize := xorStride * height
+	andSize := andStride * height
+
+	data := make([]byte, bmpInfoHeaderLen+xorSize+andSize)
+
+	// BITMAPINFOHEADER; the height covers both the XOR bitmap and the AND mask
+	le := binary.LittleEndian
+	le.PutUint32(data[0:], bmpInfoHeaderLen)
+	le.PutUint32(data[4:], uint32(width))
+	le.PutUint32(data[8:], uint32(height*2))
+	le.PutUint16(data[12:], 1)
+	le.PutUint16(data[14:], 32)
+	le.PutUint32(data[20:], uint32(xorSize+andSize))
+
+	xor := data[bmpInfoHeaderLen : bmpInfoHeaderLen+xorSize]
+	and := data[bmpInfoHeaderLen+xorSize:]
+	for y := 0; y < height; y++ {
+		row := height - 1 - y
+		for x := 0; x < width; x++ {
+			c := color.NRGBAModel.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.NRGBA)
+
+			i := row*xorStride + x*4
+			xor[i], xor[i+1], xor[i+2], xor[i+3] = c.B, c.G, c.R, c.A
+
+			// A set AND bit marks the pixel as transparent
+			if c.A == 0 {
+				and[row*andStride+x/8] |= 0x80 >> (x % 8)
+			}
+		}
+	}
+
+	return ICOEntry{
+		Width:    width,
+		Height:   height,
+		BitCount: 32,
+		Format:   ICOFormatBMP,
+		Data:     data,
+	}, nil
+}
+
+// Image decodes the entry payload into an image
+func (e ICOEntry) Image() (image.Image, error) {
+	if e.Format == ICOFormatPNG {
+		img, err := png.Decode(bytes.NewReader(e.Data))
+		if err != nil {
+			return nil, fmt.Errorf("invalid PNG entry: %w", err)
+		}
+		return img, nil
+	}
+	return decodeDIB(e.Data)
+}
+
+// decodeDIB decodes an ICO BMP payload (BITMAPINFOHEADER, optional palette,
+// XOR bitmap and AND mask) into an image
+func decodeDIB(data []byte) (image.Image, error) {
+	if len(data) < bmpInfoHeaderLen {
+		return nil, fmt.Errorf("truncated BMP entry")
+	}
+
+	le := binary.LittleEndian
+	headerLen := int(le.Uint32(data[0:]))
+	width := int(int32(le.Uint32(data[4:])))
+	height := int(int32(le.Uint32(data[8:]))) / 2
+	bitCount := int(le.Uint16(data[14:]))
+	compression := le.Uint32(data[16:])
+	colorsUsed := int(le.Uint32(data[32:]))
+
+	if headerLen < bmpInfoHeaderLen || width <= 0 || height <= 0 || width > icoMaxDimension || height > icoMaxDimension {
+		return nil, fmt.Errorf("invalid BMP header")
+	}
+	if compression != 0 {
+		return nil, fmt.Errorf("compressed BMP entries are not supported")
+	}
+
+	var palette []color.NRGBA
+	switch bitCount {
+	case 1, 4, 8:
+		if colorsUsed == 0 {
+			colorsUsed = 1 << bitCount
+		}
+		if colorsUsed > 1<<bitCount {
+			return nil, fmt.Errorf("invalid BMP palette size %d", colorsUsed)
+		}
+		if headerLen+colorsUsed*4 > len(data) {
+			return nil, fmt.Errorf("truncated BMP palette")
+		}
+		palette = make([]color.NRGBA, colorsUsed)
+	case 24, 32:
+	default:
+		return nil, fmt.Errorf("unsupported BMP bit depth %d", bitCount)
+	}
+
+	offset := headerLen
+	if offset+len(palette)*4 > len(data) {
+		return nil, fmt.Errorf("truncated BMP palette")
+	}
+	for i := range palette {
+		p := data[offset+i*4:]
+		palette[i] = color.NRGBA{R: p[2], G: p[1], B: p[0], A: 0xff}
+	}
+	offset += len(palette) * 4
+
+	xorStride := (width*bitCount + 31) / 32 * 4
+	andStride := (width + 31) / 32 * 4
+	xor := data[offset:]
+	if len(xor) < xorStride*height {
+		return nil, fmt.Errorf("truncated BMP bitmap")
+	}
+	and := xor[xorStride*height:]
+	hasMask := len(and) >= andStride*height
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	hasAlpha := false
+	for y := 0; y < height; y++ {
+		row := xor[(height-1-y)*xorStride:]
+		for x := 0; x < width; x++ {
+			var c color.NRGBA
+			switch bitCount {
+			case 32:
+				c = color.NRGBA{R: row[x*4+2], G: row[x*4+1], B: row[x*4], A: row[x*4+3]}
+				hasAlpha = hasAlpha || c.A != 0
+			case 24:
+				c = color.NRGBA{R: row[x*3+2], G: row[x*3+1], B: row[x*3], A: 0xff}
+			default:
+				bit := x * bitCount
+				index := int(row[bit/8]>>(8-bitCount-bit%8)) & (1<<bitCount - 1)
+				if index < len(palette) {
+					c = palette[index]
+				}
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+
+	// Without an alpha channel, transparency comes from the AND mask
+	if bitCount == 32 && hasAlpha || !hasMask {
+		return img, nil
+	}
+	for y := 0; y < height; y++ {
+		row := and[(height-1-y)*andStride:]
+		for x := 0; x < width; x++ {
+			i := img.PixOffset(x, y)
+			if row[x/8]&(0x80>>(x%8)) != 0 {
+				img.Pix[i+3] = 0
+			} else {
+				img.Pix[i+3] = 0xff
+			}
+		}
+	}
+
+	return img, nil
+}
+
+// ReadICO decodes the ICO file at path
+func ReadICO(path string) ([]ICOEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return DecodeICO(f)
+}
+
+// LargestICOEntry returns the entry with the largest area, preferring the
+// highest bit depth when several entries share the same dimensions
+func LargestICOEntry(entries []ICOEntry) (ICOEntry, error) {
+	if len(entries) == 0 {
+		return ICOEntry{}, fmt.Errorf("ICO file contains no images")
+	}
+
+	best := entries[0]
+	for _, e := range entries[1:] {
+		area, bestArea := e.Width*e.Height, best.Width*best.Height
+		if area > bestArea || area == bestArea && e.BitCount > best.BitCount {
+			best = e
+		}
+	}
+	return best, nil
+}
+
+// newEncodedEntry creates an ICO entry from PNG data using the given encoding
+func newEncodedEntry(data []byte, encoding ICOEncoding) (ICOEntry, error) {
+	useBMP := encoding == ICOEncodingBMP
+	if encoding == ICOEncodingLegacy {
+		cfg, err := png.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return ICOEntry{}, fmt.Errorf("invalid PNG data: %w", err)
+		}
+		useBMP = cfg.Width <= legacyBMPMaxSize && cfg.Height <= legacyBMPMaxSize
+	}
+
+	if !useBMP {
+		return NewPNGEntry(data)
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return ICOEntry{}, fmt.Errorf("invalid PNG data: %w", err)
+	}
+	return NewBMPEntry(img)
+}
+
+// EncodeICO writes the entries as an ICO file to w
+func EncodeICO(w io.Writer, entries []ICOEntry) error {
+	if len(entries) == 0 {
+		return fmt.Errorf("ICO file requires at least one image")
+	}
+
+	header := icoDir{Type: 1, Count: uint16(len(entries))}
+	if err := binary.Write(w, binary.LittleEndian, header); err != nil {
+		return err
+	}
+
+	offset := icoHeaderSize + icoEntrySize*len(entries)
+	for _, e := range entries {
+		if e.Width <= 0 || e.Height <= 0 || e.Width > icoMaxDimension || e.Height > icoMaxDimension {
+			return fmt.Errorf("ICO entry size %dx%d out of range (1-%d)", e.Width, e.Height, icoMaxDimension)
+		}
+
+		dirEntry := icoDirEntry{
+			// A dimension of 256 is stored as 0
+			Width:       uint8(e.Width % icoMaxDimension),
+			Height:      uint8(e.Height % icoMaxDimension),
+			Planes:      1,
+			BitCount:    uint16(e.BitCount),
+			BytesInRes:  uint32(len(e.Data)),
+			ImageOffset: uint32(offset),
+		}
+		if err := binary.Write(w, binary.LittleEndian, dirEntry); err != nil {
+			return err
+		}
+		offset += len(e.Data)
+	}
+
+	for _, e := range entries {
+		if _, err := w.Write(e.Data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DecodeICO parses an ICO file and returns its entries in directory order
+func DecodeICO(r io.Reader) ([]ICOEntry, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var header icoDir
+	if err := binary.Read(bytes.NewReader(data), binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("invalid ICO header: %w", err)
+	}
+	if header.Reserved != 0 || header.Type != 1 {
+		return nil, fmt.Errorf("not an ICO file")
+	}
+
+	dirEntries := make([]icoDirEntry, header.Count)
+	dir := bytes.NewReader(data[icoHeaderSize:])
+	if err := binary.Read(dir, binary.LittleEndian, dirEntries); err != nil {
+		return nil, fmt.Errorf("invalid ICO directory: %w", err)
+	}
+
+	entries := make([]ICOEntry, 0, len(dirEntries))
+	for i, d := range dirEntries {
+		start, end := int(d.ImageOffset), int(d.ImageOffset)+int(d.BytesInRes)
+		if start < icoHeaderSize || end > len(data) || start > end {
+			return nil, fmt.Errorf("ICO entry %d points outside the file", i)
+		}
+
+		entry := ICOEntry{
+			Width:    int(d.Width),
+			Height:   int(d.Height),
+			BitCount: int(d.BitCount),
+			Format:   ICOFormatBMP,
+			Data:     data[start:end],
+		}
+		// A dimension of 0 means 256
+		if entry.Width == 0 {
+			entry.Width = icoMaxDimension
+		}
+		if entry.Height == 0 {
+			entry.Height = icoMaxDimension
+		}
+
+		if bytes.HasPrefix(entry.Data, []byte(pngSignature)) {
+			entry.Format = ICOFormatPNG
+		} else if entry.BitCount == 0 && len(entry.Data) >= bmpInfoHeaderLen {
+			// Some encoders leave the directory bit count empty for DIBs
+			entry.BitCount = int(binary.LittleEndian.Uint16(entry.Data[14:]))
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// WriteICO assembles a multi-resolution ICO file from PNG files, encoding each
+// entry according to encoding
+func WriteICO(inputPaths []string, outputPath string, encoding ICOEncoding) error {
+	entries := make([]ICOEntry, 0, len(inputPaths))
+	for _, path := range inputPaths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		entry, err := newEncodedEntry(data, encoding)
+		if err != nil {
+			return fmt.Errorf("failed to add %s: %w", path, err)
+		}
+		entries = append(entries, entry)
+	}
+
+	var buf bytes.Buffer
+	if err := EncodeICO(&buf, entries); err != nil {
+		return fmt.Errorf("failed to encode ICO: %w", err)
+	}
+
+	if err := os.WriteFile(outputPath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write ICO file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,447 @@
+package processor
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// parseTestICO reads back the header, directory and payloads of an ICO file
+func parseTestICO(t *testing.T, data []byte) (icoDir, []icoDirEntry) {
+	t.Helper()
+	r := bytes.NewReader(data)
+
+	var header icoDir
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		t.Fatalf("failed to read ICONDIR: %v", err)
+	}
+
+	entries := make([]icoDirEntry, header.Count)
+	if err := binary.Read(r, binary.LittleEndian, entries); err != nil {
+		t.Fatalf("failed to read ICONDIRENTRY: %v", err)
+	}
+
+	return header, entries
+}
+
+func TestWriteICO(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	sizes := []int{16, 32, 48, 256}
+	var inputs []string
+	for _, size := range sizes {
+		path := filepath.Join(tmpDir, fmt.Sprintf("favicon-%dx%d.png", size, size))
+		writeTestPNG(t, path, size, size)
+		inputs = append(inputs, path)
+	}
+
+	output := filepath.Join(tmpDir, "favicon.ico")
+	if err := WriteICO(inputs, output, ICOEncodingPNG); err != nil {
+		t.Fatalf("WriteICO() error = %v", err)
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("failed to read ICO: %v", err)
+	}
+
+	header, entries := parseTestICO(t, data)
+	if header.Reserved != 0 || header.Type != 1 {
+		t.Errorf("header = %+v, want reserved 0 and type 1", header)
+	}
+	if int(header.Count) != len(sizes) {
+		t.Fatalf("entry count = %d, want %d", header.Count, len(sizes))
+	}
+
+	for i, e := range entries {
+		wantDim := uint8(sizes[i] % 256)
+		if e.Width != wantDim || e.Height != wantDim {
+			t.Errorf("entry[%d] dimensions = %dx%d, want %dx%d", i, e.Width, e.Height, wantDim, wantDim)
+		}
+		if e.Planes != 1 || e.BitCount != 32 {
+			t.Errorf("entry[%d] planes/bpp = %d/%d, want 1/32", i, e.Planes, e.BitCount)
+		}
+
+		end := int(e.ImageOffset) + int(e.BytesInRes)
+		if end > len(data) {
+			t.Fatalf("entry[%d] payload exceeds file size", i)
+		}
+
+		payload := data[e.ImageOffset:end]
+		cfg, err := png.DecodeConfig(bytes.NewReader(payload))
+		if err != nil {
+			t.Fatalf("entry[%d] payload is not a PNG: %v", i, err)
+		}
+		if cfg.Width != sizes[i] || cfg.Height != sizes[i] {
+			t.Errorf("entry[%d] PNG size = %dx%d, want %dx%d", i, cfg.Width, cfg.Height, sizes[i], sizes[i])
+		}
+	}
+}
+
+func TestWriteICOErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	notPNG := filepath.Join(tmpDir, "not.png")
+	if err := os.WriteFile(notPNG, []byte("not a png"), 0644); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+
+	tooLarge := filepath.Join(tmpDir, "large.png")
+	writeTestPNG(t, tooLarge, 512, 512)
+
+	tests := []struct {
+		name   string
+		inputs []string
+	}{
+		{name: "no inputs", inputs: nil},
+		{name: "missing file", inputs: []string{filepath.Join(tmpDir, "missing.png")}},
+		{name: "invalid PNG", inputs: []string{notPNG}},
+		{name: "oversized image", inputs: []string{tooLarge}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := WriteICO(tt.inputs, filepath.Join(tmpDir, "favicon.ico"), ICOEncodingPNG); err == nil {
+				t.Error("expected error from WriteICO()")
+			}
+		})
+	}
+}
+
+func TestNativeProcessorConvertToICO(t *testing.T) {
+	tmpDir := t.TempDir()
+	input := filepath.Join(tmpDir, "favicon-16x16.png")
+	writeTestPNG(t, input, 16, 16)
+
+	output := filepath.Join(tmpDir, "favicon.ico")
+	p := &NativeProcessor{}
+	if err := p.ConvertToICO(context.Background(), []string{input}, output); err != nil {
+		t.Fatalf("ConvertToICO() error = %v", err)
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("failed to read ICO: %v", err)
+	}
+	if header, _ := parseTestICO(t, data); header.Count != 1 {
+		t.Errorf("entry count = %d, want 1", header.Count)
+	}
+}
+
+func TestNewBMPEntry(t *testing.T) {
+	// 3x2 image: the top-left pixel is transparent, the rest opaque blue
+	img := image.NewNRGBA(image.Rect(0, 0, 3, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 3; x++ {
+			img.Set(x, y, color.NRGBA{B: 255, A: 255})
+		}
+	}
+	img.Set(0, 0, color.NRGBA{})
+
+	entry, err := NewBMPEntry(img)
+	if err != nil {
+		t.Fatalf("NewBMPEntry() error = %v", err)
+	}
+
+	if entry.Format != ICOFormatBMP || entry.BitCount != 32 {
+		t.Errorf("entry format/bpp = %s/%d, want bmp/32", entry.Format, entry.BitCount)
+	}
+
+	data := entry.Data
+	le := binary.LittleEndian
+	if got := le.Uint32(data[0:]); got != 40 {
+		t.Errorf("biSize = %d, want 40", got)
+	}
+	if got := int32(le.Uint32(data[4:])); got != 3 {
+		t.Errorf("biWidth = %d, want 3", got)
+	}
+	if got := int32(le.Uint32(data[8:])); got != 4 {
+		t.Errorf("biHeight = %d, want 4 (XOR + AND)", got)
+	}
+	if got := le.Uint16(data[14:]); got != 32 {
+		t.Errorf("biBitCount = %d, want 32", got)
+	}
+
+	// XOR: 3 px * 4 bytes * 2 rows; AND: 4-byte padded row * 2 rows
+	if want := 40 + 24 + 8; len(data) != want {
+		t.Fatalf("data length = %d, want %d", len(data), want)
+	}
+
+	// Rows are bottom-up: the top image row is the second stored row
+	xor := data[40:64]
+	if got := xor[12:16]; !bytes.Equal(got, []byte{0, 0, 0, 0}) {
+		t.Errorf("transparent pixel BGRA = %v, want zeros", got)
+	}
+	if got := xor[0:4]; !bytes.Equal(got, []byte{255, 0, 0, 255}) {
+		t.Errorf("opaque pixel BGRA = %v, want [255 0 0 255]", got)
+	}
+
+	and := data[64:]
+	if and[4] != 0x80 {
+		t.Errorf("AND mask top row = %#x, want 0x80", and[4])
+	}
+	if and[0] != 0 {
+		t.Errorf("AND mask bottom row = %#x, want 0", and[0])
+	}
+}
+
+func TestWriteICOEncodings(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	sizes := []int{16, 48, 64, 256}
+	var inputs []string
+	for _, size := range sizes {
+		path := filepath.Join(tmpDir, fmt.Sprintf("favicon-%dx%d.png", size, size))
+		writeTestPNG(t, path, size, size)
+		inputs = append(inputs, path)
+	}
+
+	tests := []struct {
+		encoding ICOEncoding
+		wantBMP  []bool
+	}{
+		{encoding: ICOEncodingPNG, wantBMP: []bool{false, false, false, false}},
+		{encoding: ICOEncodingBMP, wantBMP: []bool{true, true, true, true}},
+		{encoding: ICOEncodingLegacy, wantBMP: []bool{true, true, false, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.encoding), func(t *testing.T) {
+			output := filepath.Join(tmpDir, "favicon-"+string(tt.encoding)+".ico")
+			if err := WriteICO(inputs, output, tt.encoding); err != nil {
+				t.Fatalf("WriteICO() error = %v", err)
+			}
+
+			data, err := os.ReadFile(output)
+			if err != nil {
+				t.Fatalf("failed to read ICO: %v", err)
+			}
+
+			_, entries := parseTestICO(t, data)
+			for i, e := range entries {
+				payload := data[e.ImageOffset : e.ImageOffset+e.BytesInRes]
+				isPNG := bytes.HasPrefix(payload, []byte("\x89PNG"))
+				if isPNG == tt.wantBMP[i] {
+					t.Errorf("entry[%d] (%dpx) BMP = %v, want %v", i, sizes[i], !isPNG, tt.wantBMP[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseICOEncoding(t *testing.T) {
+	for _, valid := range []string{"png", "bmp", "legacy"} {
+		if got, err := ParseICOEncoding(valid); err != nil || string(got) != valid {
+			t.Errorf("ParseICOEncoding(%q) = %q, %v", valid, got, err)
+		}
+	}
+
+	if _, err := ParseICOEncoding("jpeg"); err == nil {
+		t.Error("expected error for unknown encoding")
+	}
+}
+
+func TestDecodeICO(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	sizes := []int{16, 32, 256}
+	var inputs []string
+	for _, size := range sizes {
+		path := filepath.Join(tmpDir, fmt.Sprintf("favicon-%dx%d.png", size, size))
+		writeTestPNG(t, path, size, size)
+		inputs = append(inputs, path)
+	}
+
+	output := filepath.Join(tmpDir, "favicon.ico")
+	if err := WriteICO(inputs, output, ICOEncodingLegacy); err != nil {
+		t.Fatalf("WriteICO() error = %v", err)
+	}
+
+	f, err := os.Open(output)
+	if err != nil {
+		t.Fatalf("failed to open ICO: %v", err)
+	}
+	defer f.Close()
+
+	entries, err := DecodeICO(f)
+	if err != nil {
+		t.Fatalf("DecodeICO() error = %v", err)
+	}
+
+	if len(entries) != len(sizes) {
+		t.Fatalf("decoded %d entries, want %d", len(entries), len(sizes))
+	}
+
+	wantFormats := []ICOFormat{ICOFormatBMP, ICOFormatBMP, ICOFormatPNG}
+	for i, e := range entries {
+		if e.Width != sizes[i] || e.Height != sizes[i] {
+			t.Errorf("entry[%d] size = %dx%d, want %dx%d", i, e.Width, e.Height, sizes[i], sizes[i])
+		}
+		if e.BitCount != 32 {
+			t.Errorf("entry[%d] bpp = %d, want 32", i, e.BitCount)
+		}
+		if e.Format != wantFormats[i] {
+			t.Errorf("entry[%d] format = %s, want %s", i, e.Format, wantFormats[i])
+		}
+	}
+}
+
+func TestDecodeICOErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty", data: nil},
+		{name: "cursor type", data: []byte{0, 0, 2, 0, 0, 0}},
+		{name: "truncated directory", data: []byte{0, 0, 1, 0, 1, 0, 16, 16}},
+		{
+			name: "payload out of range",
+			data: []byte{
+				0, 0, 1, 0, 1, 0,
+				16, 16, 0, 0, 1, 0, 32, 0, 100, 0, 0, 0, 22, 0, 0, 0,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeICO(bytes.NewReader(tt.data)); err == nil {
+				t.Error("expected error from DecodeICO()")
+			}
+		})
+	}
+}
+
+func TestICOEntryImageRoundTrip(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	for i := range src.Pix {
+		src.Pix[i] = 0x80
+	}
+	src.SetNRGBA(1, 2, color.NRGBA{})
+
+	bmp, err := NewBMPEntry(src)
+	if err != nil {
+		t.Fatalf("NewBMPEntry() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatalf("failed to encode PNG: %v", err)
+	}
+	pngEntry, err := NewPNGEntry(buf.Bytes())
+	if err != nil {
+		t.Fatalf("NewPNGEntry() error = %v", err)
+	}
+
+	for _, entry := range []ICOEntry{bmp, pngEntry} {
+		t.Run(string(entry.Format), func(t *testing.T) {
+			img, err := entry.Image()
+			if err != nil {
+				t.Fatalf("Image() error = %v", err)
+			}
+			if got := img.Bounds().Size(); got.X != 4 || got.Y != 4 {
+				t.Fatalf("image size = %dx%d, want 4x4", got.X, got.Y)
+			}
+			if got := color.NRGBAModel.Convert(img.At(1, 2)).(color.NRGBA); got.A != 0 {
+				t.Errorf("transparent pixel alpha = %d, want 0", got.A)
+			}
+			if got := color.NRGBAModel.Convert(img.At(0, 0)).(color.NRGBA); got != (color.NRGBA{0x80, 0x80, 0x80, 0x80}) {
+				t.Errorf("pixel (0,0) = %v, want {128 128 128 128}", got)
+			}
+		})
+	}
+}
+
+func TestICOEntryImagePaletted(t *testing.T) {
+	// 2x2 1bpp DIB: palette black/white, top row white/black, bottom-left masked
+	data := make([]byte, 40+8+8+8)
+	le := binary.LittleEndian
+	le.PutUint32(data[0:], 40)
+	le.PutUint32(data[4:], 2)
+	le.PutUint32(data[8:], 4)
+	le.PutUint16(data[12:], 1)
+	le.PutUint16(data[14:], 1)
+	copy(data[44:], []byte{0xff, 0xff, 0xff, 0})
+	// XOR rows are bottom-up: bottom row first
+	data[48] = 0x00
+	data[52] = 0x80
+	// AND mask: bottom-left pixel transparent
+	data[56] = 0x80
+
+	img, err := ICOEntry{Format: ICOFormatBMP, Data: data}.Image()
+	if err != nil {
+		t.Fatalf("Image() error = %v", err)
+	}
+
+	tests := []struct {
+		x, y int
+		want color.NRGBA
+	}{
+		{0, 0, color.NRGBA{255, 255, 255, 255}},
+		{1, 0, color.NRGBA{0, 0, 0, 255}},
+		{0, 1, color.NRGBA{0, 0, 0, 0}},
+		{1, 1, color.NRGBA{0, 0, 0, 255}},
+	}
+	for _, tt := range tests {
+		if got := color.NRGBAModel.Convert(img.At(tt.x, tt.y)).(color.NRGBA); got != tt.want {
+			t.Errorf("pixel (%d,%d) = %v, want %v", tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestICOEntryImageInvalidPalette(t *testing.T) {
+	newDIB := func(colorsUsed uint32, paletteLen int) []byte {
+		data := make([]byte, 40+paletteLen)
+		le := binary.LittleEndian
+		le.PutUint32(data[0:], 40)
+		le.PutUint32(data[4:], 2)
+		le.PutUint32(data[8:], 4)
+		le.PutUint16(data[12:], 1)
+		le.PutUint16(data[14:], 8)
+		le.PutUint32(data[32:], colorsUsed)
+		return data
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "more colors than bit depth", data: newDIB(0xF0000000, 0)},
+		{name: "palette past end of entry", data: newDIB(256, 16)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := (ICOEntry{Format: ICOFormatBMP, Data: tt.data}).Image(); err == nil {
+				t.Error("expected error from Image()")
+			}
+		})
+	}
+}
+
+func TestLargestICOEntry(t *testing.T) {
+	entries := []ICOEntry{
+		{Width: 32, Height: 32, BitCount: 32},
+		{Width: 48, Height: 48, BitCount: 8},
+		{Width: 48, Height: 48, BitCount: 32},
+		{Width: 16, Height: 16, BitCount: 32},
+	}
+
+	got, err := LargestICOEntry(entries)
+	if err != nil {
+		t.Fatalf("LargestICOEntry() error = %v", err)
+	}
+	if got.Width != 48 || got.BitCount != 32 {
+		t.Errorf("LargestICOEntry() = %dx%d@%d, want 48x48@32", got.Width, got.Height, got.BitCount)
+	}
+
+	if _, err := LargestICOEntry(nil); err == nil {
+		t.Error("expected error for empty entries")
+	}
+}
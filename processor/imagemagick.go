@@ -0,0 +1,148 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+func init() {
+	Register("imagemagick", func() Processor { return &ImageMagickProcessor{} }, 300)
+}
+
+// ImageMagickProcessor implements image processing using ImageMagick
+type ImageMagickProcessor struct{}
+
+func (p *ImageMagickProcessor) Name() string {
+	return "imagemagick"
+}
+
+func (p *ImageMagickProcessor) IsAvailable() bool {
+	// ImageMagick 7+ uses 'magick', older versions use 'convert'
+	return commandExists("magick") || commandExists("convert")
+}
+
+func (p *ImageMagickProcessor) Capabilities() Capabilities {
+	inputs := []string{"png", "jpg", "jpeg", "gif", "bmp", "webp"}
+	if p.canReadSVG() {
+		inputs = append(inputs, "svg")
+	}
+
+	return Capabilities{
+		InputFormats:  inputs,
+		OutputFormats: []string{"png", "ico"},
+		ICO:           true,
+		Upscale:       true,
+	}
+}
+
+// canReadSVG checks whether the installed ImageMagick has an SVG reader
+func (p *ImageMagickProcessor) canReadSVG() bool {
+	cmdName := "convert"
+	if commandExists("magick") {
+		cmdName = "magick"
+	}
+
+	output, err := exec.Command(cmdName, "-list", "format").Output()
+	if err != nil {
+		return false
+	}
+	return formatListCanRead(string(output), "SVG")
+}
+
+// formatListCanRead reports whether `-list format` output marks format as
+// readable. Lines look like "      SVG  SVG       rw+   Scalable Vector Graphics".
+func formatListCanRead(list, format string) bool {
+	for _, line := range strings.Split(list, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || strings.TrimSuffix(fields[0], "*") != format {
+			continue
+		}
+		return strings.HasPrefix(fields[2], "r")
+	}
+	return false
+}
+
+// getConvertCommand returns the appropriate command for ImageMagick convert operations
+func (p *ImageMagickProcessor) getConvertCommand() (string, []string) {
+	// ImageMagick 7+ uses 'magick convert' or just 'magick'
+	if commandExists("magick") {
+		return "magick", []string{"convert"}
+	}
+	// ImageMagick 6.x uses 'convert' directly
+	return "convert", []string{}
+}
+
+func (p *ImageMagickProcessor) Resize(ctx context.Context, inputPath, outputPath string, size Size, opts ResizeOptions) error {
+	srcW, srcH, err := p.dimensions(ctx, inputPath)
+	if err != nil {
+		return fmt.Errorf("imagemagick resize failed: %w", err)
+	}
+	l := computeLayout(srcW, srcH, size, opts)
+
+	// Scale to exactly the layout size, then place it in the padded box and
+	// the box on the canvas. Extent offsets move the crop window, so they are
+	// the negated positions.
+	cmdName, baseArgs := p.getConvertCommand()
+	args := append(baseArgs,
+		inputPath,
+		"-resize", fmt.Sprintf("%dx%d!", l.width, l.height),
+		"+repage",
+		"-background", "none",
+		"-gravity", "NorthWest",
+		"-extent", fmt.Sprintf("%dx%d%+d%+d", l.box.Dx(), l.box.Dy(), l.box.Min.X-l.x, l.box.Min.Y-l.y),
+		"-extent", fmt.Sprintf("%dx%d%+d%+d", size.Width, size.Height, -l.box.Min.X, -l.box.Min.Y),
+	)
+	if opts.Monochrome {
+		if opts.Threshold > 0 {
+			args = append(args, "-channel", "A", "-threshold", fmt.Sprintf("%g%%", opts.Threshold*100), "+channel")
+		}
+		args = append(args, "-fill", "black", "-colorize", "100")
+	}
+	if opts.Background != nil {
+		args = append(args, "-background", hexColor(opts.Background), "-alpha", "remove")
+	}
+	args = append(args, outputPath)
+
+	output, err := runCommand(ctx, cmdName, args...)
+	if err != nil {
+		return fmt.Errorf("imagemagick resize failed: %w, output: %s", err, string(output))
+	}
+
+	return nil
+}
+
+// dimensions reads the pixel size of the first frame of an image
+func (p *ImageMagickProcessor) dimensions(ctx context.Context, path string) (int, int, error) {
+	cmdName, args := "identify", []string{}
+	if commandExists("magick") {
+		cmdName, args = "magick", []string{"identify"}
+	}
+	args = append(args, "-format", "%w %h\n", path)
+
+	output, err := commandOutput(ctx, cmdName, args...)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read image size: %w", err)
+	}
+
+	first, _, _ := strings.Cut(strings.TrimSpace(string(output)), "\n")
+	var w, h int
+	if _, err := fmt.Sscanf(first, "%d %d", &w, &h); err != nil || w <= 0 || h <= 0 {
+		return 0, 0, fmt.Errorf("invalid image size %q", first)
+	}
+	return w, h, nil
+}
+
+func (p *ImageMagickProcessor) ConvertToICO(ctx context.Context, inputPaths []string, outputPath string) error {
+	cmdName, baseArgs := p.getConvertCommand()
+	args := append(baseArgs, inputPaths...)
+	args = append(args, outputPath)
+
+	output, err := runCommand(ctx, cmdName, args...)
+	if err != nil {
+		return fmt.Errorf("imagemagick ico conversion failed: %w, output: %s", err, string(output))
+	}
+
+	return nil
+}
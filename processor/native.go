@@ -0,0 +1,151 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/image/draw"
+)
+
+func init() {
+	// Lowest built-in priority: only used when no external tool is installed
+	Register("native", func() Processor { return &NativeProcessor{} }, 100)
+}
+
+// NativeProcessor implements image processing in pure Go without external binaries
+type NativeProcessor struct{}
+
+func (p *NativeProcessor) Name() string {
+	return "native"
+}
+
+func (p *NativeProcessor) IsAvailable() bool {
+	// The native processor is compiled in and always available
+	return true
+}
+
+func (p *NativeProcessor) Capabilities() Capabilities {
+	return Capabilities{
+		InputFormats:  []string{"png"},
+		OutputFormats: []string{"png", "ico"},
+		ICO:           true,
+		Upscale:       true,
+	}
+}
+
+func (p *NativeProcessor) Resize(ctx context.Context, inputPath, outputPath string, size Size, opts ResizeOptions) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("native resize failed: %w", err)
+	}
+
+	src, err := decodeImage(inputPath)
+	if err != nil {
+		return fmt.Errorf("native resize failed: %w", err)
+	}
+
+	bounds := src.Bounds()
+	dst := renderCanvas(src, size, computeLayout(bounds.Dx(), bounds.Dy(), size, opts), opts)
+
+	// Decoding and scaling are not interruptible, check again before writing
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("native resize failed: %w", err)
+	}
+
+	if err := encodePNG(outputPath, dst); err != nil {
+		return fmt.Errorf("native resize failed: %w", err)
+	}
+
+	return nil
+}
+
+func (p *NativeProcessor) ConvertToICO(ctx context.Context, inputPaths []string, outputPath string) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("native ico conversion failed: %w", err)
+	}
+
+	if err := WriteICO(inputPaths, outputPath, ICOEncodingPNG); err != nil {
+		return fmt.Errorf("native ico conversion failed: %w", err)
+	}
+
+	return nil
+}
+
+// decodeImage reads and decodes a PNG source image
+func decodeImage(path string) (image.Image, error) {
+	if ext := strings.ToLower(filepath.Ext(path)); ext != ".png" {
+		return nil, fmt.Errorf("unsupported source format %s (only PNG is supported)", ext)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	img, err := png.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+
+	return img, nil
+}
+
+// encodePNG writes an image to path as PNG
+func encodePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+
+	return f.Close()
+}
+
+// renderCanvas scales src into the layout rectangle of a canvas of the given
+// size, clipping it to the padded box, applies the monochrome conversion and
+// fills the remaining area with the background
+func renderCanvas(src image.Image, size Size, l layout, opts ResizeOptions) *image.NRGBA {
+	dst := image.NewNRGBA(image.Rect(0, 0, size.Width, size.Height))
+	box := dst.SubImage(l.box).(*image.NRGBA)
+	draw.CatmullRom.Scale(box, l.rect(), src, src.Bounds(), draw.Src, nil)
+
+	if opts.Monochrome {
+		silhouette(dst, opts.Threshold)
+	}
+
+	if opts.Background != nil {
+		bg := image.NewNRGBA(dst.Bounds())
+		draw.Draw(bg, bg.Bounds(), image.NewUniform(opts.Background), image.Point{}, draw.Src)
+		draw.Draw(bg, bg.Bounds(), dst, image.Point{}, draw.Over)
+		dst = bg
+	}
+
+	return dst
+}
+
+// silhouette turns img into black pixels carrying only its alpha channel.
+// With a positive threshold, alpha above threshold becomes fully opaque and
+// everything else transparent.
+func silhouette(img *image.NRGBA, threshold float64) {
+	cutoff := threshold * 0xff
+	for i := 0; i < len(img.Pix); i += 4 {
+		a := img.Pix[i+3]
+		if threshold > 0 {
+			if float64(a) > cutoff {
+				a = 0xff
+			} else {
+				a = 0
+			}
+		}
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = 0, 0, 0, a
+	}
+}
@@ -0,0 +1,157 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTestPNG creates a solid-color PNG of the given dimensions
+func writeTestPNG(t *testing.T, path string, width, height int) {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: 255, A: 255})
+		}
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create test PNG: %v", err)
+	}
+	defer f.Close()
+
+	if err := png.Encode(f, img); err != nil {
+		t.Fatalf("failed to encode test PNG: %v", err)
+	}
+}
+
+// readTestPNG decodes a PNG file for assertions
+func readTestPNG(t *testing.T, path string) image.Image {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open PNG: %v", err)
+	}
+	defer f.Close()
+
+	img, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("failed to decode PNG: %v", err)
+	}
+	return img
+}
+
+func TestNativeProcessorName(t *testing.T) {
+	p := &NativeProcessor{}
+	if got := p.Name(); got != "native" {
+		t.Errorf("NativeProcessor.Name() = %q, want %q", got, "native")
+	}
+}
+
+func TestNativeProcessorIsAvailable(t *testing.T) {
+	p := &NativeProcessor{}
+	if !p.IsAvailable() {
+		t.Error("NativeProcessor should always be available")
+	}
+}
+
+func TestNativeProcessorResize(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	tests := []struct {
+		name          string
+		width, height int
+		size          Size
+	}{
+		{name: "square downscale", width: 64, height: 64, size: Square(16)},
+		{name: "square upscale", width: 16, height: 16, size: Square(48)},
+		{name: "wide source", width: 100, height: 50, size: Square(32)},
+		{name: "tall source", width: 50, height: 100, size: Square(32)},
+		{name: "wide canvas", width: 64, height: 64, size: Size{Width: 310, Height: 150}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := filepath.Join(tmpDir, "source.png")
+			output := filepath.Join(tmpDir, "output.png")
+			writeTestPNG(t, input, tt.width, tt.height)
+
+			p := &NativeProcessor{}
+			if err := p.Resize(context.Background(), input, output, tt.size, ResizeOptions{Upscale: true}); err != nil {
+				t.Fatalf("Resize() error = %v", err)
+			}
+
+			img := readTestPNG(t, output)
+			if got := img.Bounds().Size(); got.X != tt.size.Width || got.Y != tt.size.Height {
+				t.Errorf("output size = %dx%d, want %v", got.X, got.Y, tt.size)
+			}
+
+			// The center pixel always belongs to the source artwork
+			if _, _, _, a := img.At(tt.size.Width/2, tt.size.Height/2).RGBA(); a == 0 {
+				t.Error("center pixel is transparent, want opaque")
+			}
+		})
+	}
+}
+
+func TestNativeProcessorResizePadsNonSquare(t *testing.T) {
+	tmpDir := t.TempDir()
+	input := filepath.Join(tmpDir, "wide.png")
+	output := filepath.Join(tmpDir, "output.png")
+	writeTestPNG(t, input, 100, 50)
+
+	p := &NativeProcessor{}
+	if err := p.Resize(context.Background(), input, output, Square(32), ResizeOptions{Upscale: true}); err != nil {
+		t.Fatalf("Resize() error = %v", err)
+	}
+
+	img := readTestPNG(t, output)
+	if _, _, _, a := img.At(16, 0).RGBA(); a != 0 {
+		t.Errorf("top padding alpha = %d, want 0", a)
+	}
+	if _, _, _, a := img.At(16, 31).RGBA(); a != 0 {
+		t.Errorf("bottom padding alpha = %d, want 0", a)
+	}
+}
+
+func TestNativeProcessorResizeUnsupportedFormat(t *testing.T) {
+	tmpDir := t.TempDir()
+	input := filepath.Join(tmpDir, "source.svg")
+	if err := os.WriteFile(input, []byte("<svg/>"), 0644); err != nil {
+		t.Fatalf("failed to create source: %v", err)
+	}
+
+	p := &NativeProcessor{}
+	if err := p.Resize(context.Background(), input, filepath.Join(tmpDir, "out.png"), Square(16), ResizeOptions{}); err == nil {
+		t.Error("expected error for SVG source")
+	}
+}
+
+func TestNativeProcessorCanceled(t *testing.T) {
+	tmpDir := t.TempDir()
+	input := filepath.Join(tmpDir, "source.png")
+	writeTestPNG(t, input, 32, 32)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	p := &NativeProcessor{}
+	output := filepath.Join(tmpDir, "output.png")
+	if err := p.Resize(ctx, input, output, Square(16), ResizeOptions{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Resize() error = %v, want %v", err, context.Canceled)
+	}
+	if _, err := os.Stat(output); !os.IsNotExist(err) {
+		t.Error("output written despite canceled context")
+	}
+
+	if err := p.ConvertToICO(ctx, []string{input}, filepath.Join(tmpDir, "favicon.ico")); !errors.Is(err, context.Canceled) {
+		t.Errorf("ConvertToICO() error = %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,223 @@
+package processor
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Fit selects how the source is scaled into the output canvas
+type Fit string
+
+const (
+	// FitContain scales the source to fit entirely inside the canvas,
+	// padding the remaining area
+	FitContain Fit = "contain"
+
+	// FitCover scales the source to cover the whole canvas, cropping the
+	// overflow
+	FitCover Fit = "cover"
+
+	// FitFill stretches the source to the canvas, ignoring its aspect ratio
+	FitFill Fit = "fill"
+)
+
+// ParseFit validates a fit mode name
+func ParseFit(s string) (Fit, error) {
+	switch fit := Fit(s); fit {
+	case FitContain, FitCover, FitFill:
+		return fit, nil
+	default:
+		return "", fmt.Errorf("unknown fit mode %q (expected contain, cover or fill)", s)
+	}
+}
+
+// Gravity selects which part of the canvas the source is anchored to when
+// it is padded or cropped
+type Gravity string
+
+const (
+	GravityCenter    Gravity = "center"
+	GravityNorth     Gravity = "north"
+	GravitySouth     Gravity = "south"
+	GravityEast      Gravity = "east"
+	GravityWest      Gravity = "west"
+	GravityNorthEast Gravity = "northeast"
+	GravityNorthWest Gravity = "northwest"
+	GravitySouthEast Gravity = "southeast"
+	GravitySouthWest Gravity = "southwest"
+)
+
+// ParseGravity validates a gravity name
+func ParseGravity(s string) (Gravity, error) {
+	switch g := Gravity(s); g {
+	case GravityCenter, GravityNorth, GravitySouth, GravityEast, GravityWest,
+		GravityNorthEast, GravityNorthWest, GravitySouthEast, GravitySouthWest:
+		return g, nil
+	default:
+		return "", fmt.Errorf("unknown gravity %q (expected center, north, south, east, west, northeast, northwest, southeast or southwest)", s)
+	}
+}
+
+// anchor returns the horizontal and vertical alignment of the gravity as
+// fractions of the free space: 0 for start, 0.5 for center, 1 for end
+func (g Gravity) anchor() (float64, float64) {
+	ax, ay := 0.5, 0.5
+	switch g {
+	case GravityNorth, GravityNorthEast, GravityNorthWest:
+		ay = 0
+	case GravitySouth, GravitySouthEast, GravitySouthWest:
+		ay = 1
+	}
+	switch g {
+	case GravityWest, GravityNorthWest, GravitySouthWest:
+		ax = 0
+	case GravityEast, GravityNorthEast, GravitySouthEast:
+		ax = 1
+	}
+	return ax, ay
+}
+
+// Padding is the empty margin kept on every side of the canvas, either in
+// pixels or as a percentage of the shorter canvas side
+type Padding struct {
+	Value   float64
+	Percent bool
+}
+
+// ParsePadding parses a padding such as "12%", "8px" or "8"
+func ParsePadding(s string) (Padding, error) {
+	s = strings.TrimSpace(s)
+	var p Padding
+	num := s
+	switch {
+	case strings.HasSuffix(s, "%"):
+		num, p.Percent = strings.TrimSuffix(s, "%"), true
+	case strings.HasSuffix(s, "px"):
+		num = strings.TrimSuffix(s, "px")
+	}
+
+	value, err := strconv.ParseFloat(num, 64)
+	if err != nil || value < 0 || math.IsInf(value, 0) {
+		return Padding{}, fmt.Errorf("invalid padding %q (expected pixels or a percentage such as 12%%)", s)
+	}
+	if p.Percent && value >= 50 {
+		return Padding{}, fmt.Errorf("padding must be less than 50%%: %s", s)
+	}
+	p.Value = value
+	return p, nil
+}
+
+// Pixels returns the padding in pixels for a canvas whose shorter side is
+// size
+func (p Padding) Pixels(size int) int {
+	if p.Percent {
+		return int(math.Round(float64(size) * p.Value / 100))
+	}
+	return int(math.Round(p.Value))
+}
+
+// ResizeOptions controls how the source is placed on the output canvas.
+// Every processor honors the same contract: the output is always exactly
+// the requested width x height, Padding is kept free on every side, the source is
+// scaled into the remaining box according to Fit and anchored by Gravity,
+// and any area it does not cover shows Background.
+type ResizeOptions struct {
+	// Fit selects the scaling mode; empty means FitContain
+	Fit Fit
+
+	// Gravity anchors the source on the canvas; empty means GravityCenter
+	Gravity Gravity
+
+	// Upscale allows enlarging sources smaller than the canvas. When false,
+	// such sources keep their original size and are only padded.
+	Upscale bool
+
+	// Padding is the margin around the source
+	Padding Padding
+
+	// Background fills the canvas behind the source; nil means transparent
+	Background color.Color
+
+	// Monochrome replaces the source with a black silhouette of its alpha
+	// channel, as used by themed icons
+	Monochrome bool
+
+	// Threshold makes silhouette pixels with an alpha above this fraction
+	// fully opaque and the rest transparent; zero keeps the source alpha
+	Threshold float64
+}
+
+// ParseThreshold validates a monochrome alpha threshold between 0 and 1
+func ParseThreshold(s string) (float64, error) {
+	t, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || t < 0 || t >= 1 {
+		return 0, fmt.Errorf("invalid threshold %q (expected a value from 0 to below 1)", s)
+	}
+	return t, nil
+}
+
+// layout describes where the scaled source lands on the output canvas
+type layout struct {
+	// Size of the scaled source
+	width, height int
+
+	// Position of the scaled source on the canvas
+	x, y int
+
+	// Canvas area inside the padding
+	box image.Rectangle
+}
+
+// rect returns the canvas rectangle covered by the scaled source
+func (l layout) rect() image.Rectangle {
+	return image.Rect(l.x, l.y, l.x+l.width, l.y+l.height)
+}
+
+// computeLayout places a srcW x srcH source on a canvas of the given size
+// according to opts. Cover layouts may extend beyond the padded box; the
+// overflow is cropped.
+func computeLayout(srcW, srcH int, size Size, opts ResizeOptions) layout {
+	pad := opts.Padding.Pixels(size.Min())
+	innerW := max(1, size.Width-2*pad)
+	innerH := max(1, size.Height-2*pad)
+	insetX := (size.Width - innerW) / 2
+	insetY := (size.Height - innerH) / 2
+
+	scaleX := float64(innerW) / float64(srcW)
+	scaleY := float64(innerH) / float64(srcH)
+
+	switch opts.Fit {
+	case FitFill:
+		// Scale each axis independently
+	case FitCover:
+		scaleX = math.Max(scaleX, scaleY)
+		scaleY = scaleX
+	default:
+		scaleX = math.Min(scaleX, scaleY)
+		scaleY = scaleX
+	}
+
+	if !opts.Upscale {
+		scaleX = math.Min(scaleX, 1)
+		scaleY = math.Min(scaleY, 1)
+	}
+
+	w := max(1, int(math.Round(float64(srcW)*scaleX)))
+	h := max(1, int(math.Round(float64(srcH)*scaleY)))
+	if opts.Fit != FitCover {
+		w, h = min(innerW, w), min(innerH, h)
+	}
+
+	ax, ay := opts.Gravity.anchor()
+	return layout{
+		width:  w,
+		height: h,
+		x:      insetX + int(float64(innerW-w)*ax),
+		y:      insetY + int(float64(innerH-h)*ay),
+		box:    image.Rect(insetX, insetY, size.Width-insetX, size.Height-insetY),
+	}
+}
@@ -0,0 +1,303 @@
+package processor
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"path/filepath"
+	"testing"
+)
+
+// opaqueBounds returns the bounding box of non-transparent pixels
+func opaqueBounds(img image.Image) image.Rectangle {
+	var r image.Rectangle
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if _, _, _, a := img.At(x, y).RGBA(); a > 0x7fff {
+				r = r.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	return r
+}
+
+// availableBackends returns every built-in processor installed on this system
+func availableBackends(t *testing.T) []Processor {
+	t.Helper()
+	var procs []Processor
+	for _, p := range []Processor{&ImageMagickProcessor{}, &VipsProcessor{}, &NativeProcessor{}} {
+		if p.IsAvailable() {
+			procs = append(procs, p)
+		} else {
+			t.Logf("skipping %s: not installed", p.Name())
+		}
+	}
+	return procs
+}
+
+func TestComputeLayout(t *testing.T) {
+	tests := []struct {
+		name       string
+		srcW, srcH int
+		size       Size
+		opts       ResizeOptions
+		want       layout
+	}{
+		{name: "square downscale", srcW: 64, srcH: 64, size: Square(16), want: layout{16, 16, 0, 0, image.Rect(0, 0, 16, 16)}},
+		{name: "wide downscale", srcW: 100, srcH: 50, size: Square(32), want: layout{32, 16, 0, 8, image.Rect(0, 0, 32, 32)}},
+		{name: "tall downscale", srcW: 50, srcH: 100, size: Square(32), want: layout{16, 32, 8, 0, image.Rect(0, 0, 32, 32)}},
+		{name: "upscale allowed", srcW: 16, srcH: 8, size: Square(64), opts: ResizeOptions{Upscale: true}, want: layout{64, 32, 0, 16, image.Rect(0, 0, 64, 64)}},
+		{name: "upscale denied", srcW: 16, srcH: 8, size: Square(64), want: layout{16, 8, 24, 28, image.Rect(0, 0, 64, 64)}},
+		{name: "extreme aspect keeps one pixel", srcW: 1000, srcH: 1, size: Square(16), want: layout{16, 1, 0, 7, image.Rect(0, 0, 16, 16)}},
+		{name: "contain north", srcW: 100, srcH: 50, size: Square(32), opts: ResizeOptions{Gravity: GravityNorth}, want: layout{32, 16, 0, 0, image.Rect(0, 0, 32, 32)}},
+		{name: "contain southeast", srcW: 16, srcH: 8, size: Square(64), opts: ResizeOptions{Gravity: GravitySouthEast}, want: layout{16, 8, 48, 56, image.Rect(0, 0, 64, 64)}},
+		{name: "cover crops centered", srcW: 100, srcH: 50, size: Square(32), opts: ResizeOptions{Fit: FitCover}, want: layout{64, 32, -16, 0, image.Rect(0, 0, 32, 32)}},
+		{name: "cover west", srcW: 100, srcH: 50, size: Square(32), opts: ResizeOptions{Fit: FitCover, Gravity: GravityWest}, want: layout{64, 32, 0, 0, image.Rect(0, 0, 32, 32)}},
+		{name: "cover east", srcW: 100, srcH: 50, size: Square(32), opts: ResizeOptions{Fit: FitCover, Gravity: GravityEast}, want: layout{64, 32, -32, 0, image.Rect(0, 0, 32, 32)}},
+		{name: "cover upscale denied", srcW: 16, srcH: 8, size: Square(64), opts: ResizeOptions{Fit: FitCover}, want: layout{16, 8, 24, 28, image.Rect(0, 0, 64, 64)}},
+		{name: "fill stretches", srcW: 100, srcH: 50, size: Square(32), opts: ResizeOptions{Fit: FitFill}, want: layout{32, 32, 0, 0, image.Rect(0, 0, 32, 32)}},
+		{name: "percent padding", srcW: 64, srcH: 64, size: Square(100), opts: ResizeOptions{Padding: Padding{Value: 10, Percent: true}}, want: layout{64, 64, 18, 18, image.Rect(10, 10, 90, 90)}},
+		{name: "padding with upscale", srcW: 64, srcH: 64, size: Square(100), opts: ResizeOptions{Padding: Padding{Value: 10, Percent: true}, Upscale: true}, want: layout{80, 80, 10, 10, image.Rect(10, 10, 90, 90)}},
+		{name: "pixel padding with cover", srcW: 100, srcH: 50, size: Square(32), opts: ResizeOptions{Fit: FitCover, Padding: Padding{Value: 4}}, want: layout{48, 24, -8, 4, image.Rect(4, 4, 28, 28)}},
+		{name: "oversized padding keeps one pixel", srcW: 64, srcH: 64, size: Square(16), opts: ResizeOptions{Padding: Padding{Value: 20}}, want: layout{1, 1, 7, 7, image.Rect(7, 7, 9, 9)}},
+		{name: "fill upscale denied", srcW: 100, srcH: 10, size: Square(32), opts: ResizeOptions{Fit: FitFill}, want: layout{32, 10, 0, 11, image.Rect(0, 0, 32, 32)}},
+		{name: "wide canvas", srcW: 64, srcH: 64, size: Size{310, 150}, want: layout{64, 64, 123, 43, image.Rect(0, 0, 310, 150)}},
+		{name: "wide canvas upscaled", srcW: 64, srcH: 64, size: Size{310, 150}, opts: ResizeOptions{Upscale: true}, want: layout{150, 150, 80, 0, image.Rect(0, 0, 310, 150)}},
+		{name: "wide canvas cover", srcW: 100, srcH: 100, size: Size{200, 100}, opts: ResizeOptions{Fit: FitCover, Upscale: true}, want: layout{200, 200, 0, -50, image.Rect(0, 0, 200, 100)}},
+		{name: "wide canvas padding uses shorter side", srcW: 64, srcH: 64, size: Size{200, 100}, opts: ResizeOptions{Padding: Padding{Value: 10, Percent: true}, Upscale: true}, want: layout{80, 80, 60, 10, image.Rect(10, 10, 190, 90)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := computeLayout(tt.srcW, tt.srcH, tt.size, tt.opts); got != tt.want {
+				t.Errorf("computeLayout() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFitAndGravity(t *testing.T) {
+	if fit, err := ParseFit("cover"); err != nil || fit != FitCover {
+		t.Errorf("ParseFit(cover) = %q, %v", fit, err)
+	}
+	if _, err := ParseFit("stretch"); err == nil {
+		t.Error("ParseFit(stretch) should fail")
+	}
+	if g, err := ParseGravity("northwest"); err != nil || g != GravityNorthWest {
+		t.Errorf("ParseGravity(northwest) = %q, %v", g, err)
+	}
+	if _, err := ParseGravity("top"); err == nil {
+		t.Error("ParseGravity(top) should fail")
+	}
+}
+
+func TestParsePadding(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Padding
+		wantErr bool
+	}{
+		{in: "12%", want: Padding{Value: 12, Percent: true}},
+		{in: "8px", want: Padding{Value: 8}},
+		{in: "8", want: Padding{Value: 8}},
+		{in: "0", want: Padding{}},
+		{in: "-1", wantErr: true},
+		{in: "50%", wantErr: true},
+		{in: "wide", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParsePadding(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParsePadding(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParsePadding(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseThreshold(t *testing.T) {
+	if got, err := ParseThreshold("0.5"); err != nil || got != 0.5 {
+		t.Errorf("ParseThreshold(0.5) = %v, %v", got, err)
+	}
+	for _, in := range []string{"-0.1", "1", "half"} {
+		if _, err := ParseThreshold(in); err == nil {
+			t.Errorf("ParseThreshold(%q) should fail", in)
+		}
+	}
+}
+
+func TestResizeContractPerBackend(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	tests := []struct {
+		name          string
+		width, height int
+		size          Size
+		opts          ResizeOptions
+		wantContent   image.Rectangle
+	}{
+		{
+			name: "square downscale", width: 64, height: 64, size: Square(16),
+			wantContent: image.Rect(0, 0, 16, 16),
+		},
+		{
+			name: "wide source is padded to square", width: 100, height: 50, size: Square(32),
+			wantContent: image.Rect(0, 8, 32, 24),
+		},
+		{
+			name: "tall source is padded to square", width: 50, height: 100, size: Square(32),
+			wantContent: image.Rect(8, 0, 24, 32),
+		},
+		{
+			name: "small source upscaled when allowed", width: 16, height: 16, size: Square(48),
+			opts:        ResizeOptions{Upscale: true},
+			wantContent: image.Rect(0, 0, 48, 48),
+		},
+		{
+			name: "small source padded when upscale denied", width: 16, height: 16, size: Square(48),
+			wantContent: image.Rect(16, 16, 32, 32),
+		},
+		{
+			name: "contain anchored south", width: 100, height: 50, size: Square(32),
+			opts:        ResizeOptions{Gravity: GravitySouth},
+			wantContent: image.Rect(0, 16, 32, 32),
+		},
+		{
+			name: "cover fills canvas", width: 100, height: 50, size: Square(32),
+			opts:        ResizeOptions{Fit: FitCover, Gravity: GravityEast},
+			wantContent: image.Rect(0, 0, 32, 32),
+		},
+		{
+			name: "fill stretches to canvas", width: 50, height: 100, size: Square(32),
+			opts:        ResizeOptions{Fit: FitFill},
+			wantContent: image.Rect(0, 0, 32, 32),
+		},
+		{
+			name: "padding keeps margin free", width: 64, height: 64, size: Square(40),
+			opts:        ResizeOptions{Padding: Padding{Value: 25, Percent: true}},
+			wantContent: image.Rect(10, 10, 30, 30),
+		},
+		{
+			name: "padding crops cover overflow", width: 100, height: 50, size: Square(32),
+			opts:        ResizeOptions{Fit: FitCover, Padding: Padding{Value: 4}},
+			wantContent: image.Rect(4, 4, 28, 28),
+		},
+		{
+			name: "wide canvas centers square source", width: 64, height: 64, size: Size{Width: 62, Height: 30},
+			opts:        ResizeOptions{Upscale: true},
+			wantContent: image.Rect(16, 0, 46, 30),
+		},
+		{
+			name: "tall canvas anchored north", width: 64, height: 64, size: Size{Width: 20, Height: 40},
+			opts:        ResizeOptions{Gravity: GravityNorth},
+			wantContent: image.Rect(0, 0, 20, 20),
+		},
+	}
+
+	for _, p := range availableBackends(t) {
+		for _, tt := range tests {
+			t.Run(p.Name()+"/"+tt.name, func(t *testing.T) {
+				input := filepath.Join(tmpDir, p.Name()+"-source.png")
+				output := filepath.Join(tmpDir, p.Name()+"-output.png")
+				writeTestPNG(t, input, tt.width, tt.height)
+
+				if err := p.Resize(context.Background(), input, output, tt.size, tt.opts); err != nil {
+					t.Fatalf("Resize() error = %v", err)
+				}
+
+				img := readTestPNG(t, output)
+				if got := img.Bounds().Size(); got.X != tt.size.Width || got.Y != tt.size.Height {
+					t.Fatalf("output size = %dx%d, want %v", got.X, got.Y, tt.size)
+				}
+
+				// Allow one pixel of resampling bleed at the content edges
+				got := opaqueBounds(img)
+				if !got.In(tt.wantContent.Inset(-1)) || !tt.wantContent.Inset(1).In(got) {
+					t.Errorf("content bounds = %v, want %v", got, tt.wantContent)
+				}
+			})
+		}
+	}
+}
+
+func TestResizeBackgroundPerBackend(t *testing.T) {
+	tmpDir := t.TempDir()
+	white := color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+
+	for _, p := range availableBackends(t) {
+		t.Run(p.Name(), func(t *testing.T) {
+			input := filepath.Join(tmpDir, p.Name()+"-source.png")
+			output := filepath.Join(tmpDir, p.Name()+"-output.png")
+			writeTestPNG(t, input, 64, 64)
+
+			opts := ResizeOptions{Padding: Padding{Value: 8}, Background: white, Upscale: true}
+			if err := p.Resize(context.Background(), input, output, Square(48), opts); err != nil {
+				t.Fatalf("Resize() error = %v", err)
+			}
+
+			img := readTestPNG(t, output)
+			if got := color.NRGBAModel.Convert(img.At(0, 0)); got != white {
+				t.Errorf("padding pixel = %v, want %v", got, white)
+			}
+			if _, g, _, a := img.At(24, 24).RGBA(); a != 0xffff || g > 0x0fff {
+				t.Errorf("center pixel = %v, want opaque source color", img.At(24, 24))
+			}
+		})
+	}
+}
+
+func TestResizeMonochromePerBackend(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Left half mostly opaque red, right half faint red
+	src := image.NewNRGBA(image.Rect(0, 0, 64, 64))
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			a := uint8(200)
+			if x >= 32 {
+				a = 50
+			}
+			src.Set(x, y, color.NRGBA{R: 255, A: a})
+		}
+	}
+	input := filepath.Join(tmpDir, "source.png")
+	if err := encodePNG(input, src); err != nil {
+		t.Fatalf("failed to write source: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		threshold  float64
+		wantAlphas [2]uint8
+	}{
+		{name: "threshold", threshold: 0.5, wantAlphas: [2]uint8{0xff, 0}},
+		{name: "soft alpha", threshold: 0, wantAlphas: [2]uint8{200, 50}},
+	}
+
+	for _, p := range availableBackends(t) {
+		for _, tt := range tests {
+			t.Run(p.Name()+"/"+tt.name, func(t *testing.T) {
+				output := filepath.Join(tmpDir, p.Name()+"-"+tt.name+".png")
+				opts := ResizeOptions{Monochrome: true, Threshold: tt.threshold}
+				if err := p.Resize(context.Background(), input, output, Square(64), opts); err != nil {
+					t.Fatalf("Resize() error = %v", err)
+				}
+
+				img := readTestPNG(t, output)
+				for i, x := range []int{16, 48} {
+					c := color.NRGBAModel.Convert(img.At(x, 32)).(color.NRGBA)
+					if diff := int(c.A) - int(tt.wantAlphas[i]); diff < -2 || diff > 2 {
+						t.Errorf("alpha at x=%d = %d, want %d", x, c.A, tt.wantAlphas[i])
+					}
+					if c.A > 0 && (c.R != 0 || c.G != 0 || c.B != 0) {
+						t.Errorf("pixel at x=%d = %v, want black", x, c)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Processor defines the interface for image processing backends
+type Processor interface {
+	// Name returns the name of the processor
+	Name() string
+
+	// IsAvailable checks if the processor is available on the system
+	IsAvailable() bool
+
+	// Capabilities reports the formats and features the processor supports
+	Capabilities() Capabilities
+
+	// Resize renders the image onto a canvas of the given size following
+	// the ResizeOptions contract, aborting when ctx is canceled
+	Resize(ctx context.Context, inputPath, outputPath string, size Size, opts ResizeOptions) error
+
+	// ConvertToICO converts multiple PNGs to a single ICO file, aborting when
+	// ctx is canceled
+	ConvertToICO(ctx context.Context, inputPaths []string, outputPath string) error
+}
+
+// DetectAvailableProcessor detects which registered image processor is
+// available and able to meet the requirements
+func DetectAvailableProcessor(preferred string, req Requirements) (Processor, error) {
+	// If a preferred processor is specified, it must be registered, available
+	// and capable
+	if preferred != "" {
+		p, ok := lookup(preferred)
+		if !ok {
+			return nil, unknownProcessorError(preferred)
+		}
+		if !p.IsAvailable() {
+			return nil, fmt.Errorf("preferred processor %s is not available", preferred)
+		}
+		if err := p.Capabilities().Check(req); err != nil {
+			return nil, fmt.Errorf("preferred processor %s %w", preferred, err)
+		}
+		return p, nil
+	}
+
+	// Otherwise, return the first available and capable processor in
+	// priority order
+	var rejected []string
+	for _, p := range registeredProcessors() {
+		if !p.IsAvailable() {
+			continue
+		}
+		if err := p.Capabilities().Check(req); err != nil {
+			rejected = append(rejected, fmt.Sprintf("%s %v", p.Name(), err))
+			continue
+		}
+		return p, nil
+	}
+
+	if len(rejected) > 0 {
+		return nil, fmt.Errorf("no available image processor can handle this job (%s)", strings.Join(rejected, "; "))
+	}
+	return nil, fmt.Errorf("no image processor available (install ImageMagick or libvips)")
+}
+
+// commandExists checks if a command is available in PATH
+func commandExists(cmd string) bool {
+	_, err := exec.LookPath(cmd)
+	return err == nil
+}
+
+// runCommand runs an external command that is killed when ctx is canceled.
+// If the command fails because of the context, the context error is returned.
+func runCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil && ctx.Err() != nil {
+		return output, ctx.Err()
+	}
+	return output, err
+}
+
+// commandOutput runs an external command like runCommand but returns only
+// its standard output
+func commandOutput(ctx context.Context, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	output, err := cmd.Output()
+	if err != nil && ctx.Err() != nil {
+		return output, ctx.Err()
+	}
+	return output, err
+}
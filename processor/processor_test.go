@@ -1,7 +1,10 @@
 package processor
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 )
 
 func TestCommandExists(t *testing.T) {
@@ -48,7 +51,7 @@ func TestVipsProcessorName(t *testing.T) {
 
 func TestDetectAvailableProcessor(t *testing.T) {
 	t.Run("no preferred processor", func(t *testing.T) {
-		proc, err := DetectAvailableProcessor("")
+		proc, err := DetectAvailableProcessor("", Requirements{})
 		// Either we get a processor or an error about no processor available
 		if err != nil {
 			if err.Error() != "no image processor available (install ImageMagick or libvips)" {
@@ -62,7 +65,7 @@ func TestDetectAvailableProcessor(t *testing.T) {
 	})
 
 	t.Run("non-existent preferred processor", func(t *testing.T) {
-		_, err := DetectAvailableProcessor("nonexistent")
+		_, err := DetectAvailableProcessor("nonexistent", Requirements{})
 		if err == nil {
 			t.Error("expected error for non-existent processor")
 		}
@@ -98,3 +101,38 @@ func TestImageMagickGetConvertCommand(t *testing.T) {
 		t.Errorf("unexpected command name: %s", cmdName)
 	}
 }
+
+func TestRunCommandContext(t *testing.T) {
+	if !commandExists("sleep") {
+		t.Skip("sleep command not available")
+	}
+
+	t.Run("timeout kills the command", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err := runCommand(ctx, "sleep", "10")
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("runCommand() error = %v, want %v", err, context.DeadlineExceeded)
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("runCommand() took %v, want prompt termination", elapsed)
+		}
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if _, err := runCommand(ctx, "sleep", "10"); !errors.Is(err, context.Canceled) {
+			t.Errorf("runCommand() error = %v, want %v", err, context.Canceled)
+		}
+	})
+
+	t.Run("command failure", func(t *testing.T) {
+		if _, err := runCommand(context.Background(), "sleep", "invalid"); err == nil {
+			t.Error("expected error for failing command")
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package processor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Factory creates a new instance of a processor backend
+type Factory func() Processor
+
+// registration holds a registered processor backend
+type registration struct {
+	name     string
+	factory  Factory
+	priority int
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   []registration
+)
+
+// Register makes a processor backend available under name. Automatic
+// detection tries backends from the highest to the lowest priority.
+// Registering a name again replaces the previous registration.
+func Register(name string, factory Factory, priority int) {
+	if name == "" {
+		panic("processor: Register called with empty name")
+	}
+	if factory == nil {
+		panic("processor: Register called with nil factory for " + name)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	entry := registration{name: name, factory: factory, priority: priority}
+	replaced := false
+	for i := range registry {
+		if registry[i].name == name {
+			registry[i] = entry
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		registry = append(registry, entry)
+	}
+
+	// Keep the registry in detection order; ties keep registration order
+	sort.SliceStable(registry, func(i, j int) bool {
+		return registry[i].priority > registry[j].priority
+	})
+}
+
+// Registered returns the names of all registered backends in detection order
+func Registered() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for _, r := range registry {
+		names = append(names, r.name)
+	}
+	return names
+}
+
+// lookup creates the backend registered under name
+func lookup(name string) (Processor, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	for _, r := range registry {
+		if r.name == name {
+			return r.factory(), true
+		}
+	}
+	return nil, false
+}
+
+// registeredProcessors creates one instance of every backend in detection order
+func registeredProcessors() []Processor {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	processors := make([]Processor, 0, len(registry))
+	for _, r := range registry {
+		processors = append(processors, r.factory())
+	}
+	return processors
+}
+
+// unknownProcessorError reports a backend name that is not registered
+func unknownProcessorError(name string) error {
+	return fmt.Errorf("unknown processor %q (available: %s)", name, strings.Join(Registered(), ", "))
+}
@@ -0,0 +1,208 @@
+package processor
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fakeProcessor is a minimal backend used to exercise the registry
+type fakeProcessor struct {
+	name      string
+	available bool
+	caps      *Capabilities
+}
+
+func (p *fakeProcessor) Name() string      { return p.name }
+func (p *fakeProcessor) IsAvailable() bool { return p.available }
+
+func (p *fakeProcessor) Capabilities() Capabilities {
+	if p.caps != nil {
+		return *p.caps
+	}
+	return Capabilities{InputFormats: []string{"png"}, OutputFormats: []string{"png"}}
+}
+
+func (p *fakeProcessor) Resize(ctx context.Context, inputPath, outputPath string, size Size, opts ResizeOptions) error {
+	return nil
+}
+
+func (p *fakeProcessor) ConvertToICO(ctx context.Context, inputPaths []string, outputPath string) error {
+	return nil
+}
+
+// withRegistry runs fn against a scratch registry and restores the original
+func withRegistry(t *testing.T, fn func()) {
+	t.Helper()
+
+	registryMu.Lock()
+	saved := registry
+	registry = nil
+	registryMu.Unlock()
+
+	defer func() {
+		registryMu.Lock()
+		registry = saved
+		registryMu.Unlock()
+	}()
+
+	fn()
+}
+
+func fakeFactory(name string, available bool) Factory {
+	return func() Processor { return &fakeProcessor{name: name, available: available} }
+}
+
+func TestBuiltinProcessorsRegistered(t *testing.T) {
+	want := []string{"imagemagick", "vips", "native"}
+	if got := Registered(); !slices.Equal(got, want) {
+		t.Errorf("Registered() = %v, want %v", got, want)
+	}
+}
+
+func TestRegisterPriorityOrder(t *testing.T) {
+	withRegistry(t, func() {
+		Register("low", fakeFactory("low", true), 10)
+		Register("high", fakeFactory("high", true), 50)
+		Register("mid", fakeFactory("mid", true), 30)
+
+		want := []string{"high", "mid", "low"}
+		if got := Registered(); !slices.Equal(got, want) {
+			t.Errorf("Registered() = %v, want %v", got, want)
+		}
+
+		proc, err := DetectAvailableProcessor("", Requirements{})
+		if err != nil {
+			t.Fatalf("DetectAvailableProcessor() error = %v", err)
+		}
+		if proc.Name() != "high" {
+			t.Errorf("detected %q, want %q", proc.Name(), "high")
+		}
+	})
+}
+
+func TestRegisterReplaces(t *testing.T) {
+	withRegistry(t, func() {
+		Register("custom", fakeFactory("custom", false), 10)
+		Register("other", fakeFactory("other", true), 20)
+		Register("custom", fakeFactory("custom", true), 30)
+
+		want := []string{"custom", "other"}
+		if got := Registered(); !slices.Equal(got, want) {
+			t.Errorf("Registered() = %v, want %v", got, want)
+		}
+
+		proc, err := DetectAvailableProcessor("custom", Requirements{})
+		if err != nil {
+			t.Fatalf("DetectAvailableProcessor() error = %v", err)
+		}
+		if !proc.IsAvailable() {
+			t.Error("replacement registration was not used")
+		}
+	})
+}
+
+func TestDetectSkipsUnavailable(t *testing.T) {
+	withRegistry(t, func() {
+		Register("missing", fakeFactory("missing", false), 50)
+		Register("present", fakeFactory("present", true), 10)
+
+		proc, err := DetectAvailableProcessor("", Requirements{})
+		if err != nil {
+			t.Fatalf("DetectAvailableProcessor() error = %v", err)
+		}
+		if proc.Name() != "present" {
+			t.Errorf("detected %q, want %q", proc.Name(), "present")
+		}
+
+		if _, err := DetectAvailableProcessor("missing", Requirements{}); err == nil || !strings.Contains(err.Error(), "not available") {
+			t.Errorf("DetectAvailableProcessor(missing) error = %v, want not available", err)
+		}
+	})
+}
+
+func TestDetectUnknownProcessorListsNames(t *testing.T) {
+	withRegistry(t, func() {
+		Register("alpha", fakeFactory("alpha", true), 20)
+		Register("beta", fakeFactory("beta", true), 10)
+
+		_, err := DetectAvailableProcessor("gamma", Requirements{})
+		if err == nil {
+			t.Fatal("expected error for unknown processor")
+		}
+		if !strings.Contains(err.Error(), `unknown processor "gamma"`) || !strings.Contains(err.Error(), "alpha, beta") {
+			t.Errorf("error = %q, want unknown processor listing alpha, beta", err)
+		}
+	})
+}
+
+func TestDetectEmptyRegistry(t *testing.T) {
+	withRegistry(t, func() {
+		if _, err := DetectAvailableProcessor("", Requirements{}); err == nil {
+			t.Error("expected error with no registered processors")
+		}
+	})
+}
+
+func TestRegisterInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		regName string
+		factory Factory
+	}{
+		{name: "empty name", regName: "", factory: fakeFactory("x", true)},
+		{name: "nil factory", regName: "x", factory: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withRegistry(t, func() {
+				defer func() {
+					if recover() == nil {
+						t.Error("expected Register to panic")
+					}
+				}()
+				Register(tt.regName, tt.factory, 0)
+			})
+		})
+	}
+}
+
+func TestDetectSelectsCapableProcessor(t *testing.T) {
+	withRegistry(t, func() {
+		Register("rasteronly", func() Processor {
+			return &fakeProcessor{name: "rasteronly", available: true, caps: &Capabilities{
+				InputFormats:  []string{"png"},
+				OutputFormats: []string{"png"},
+			}}
+		}, 50)
+		Register("vector", func() Processor {
+			return &fakeProcessor{name: "vector", available: true, caps: &Capabilities{
+				InputFormats:  []string{"png", "svg"},
+				OutputFormats: []string{"png"},
+				ICO:           true,
+			}}
+		}, 10)
+
+		proc, err := DetectAvailableProcessor("", Requirements{InputFormat: "png"})
+		if err != nil || proc.Name() != "rasteronly" {
+			t.Errorf("png job detected %v (err %v), want rasteronly", proc, err)
+		}
+
+		proc, err = DetectAvailableProcessor("", Requirements{InputFormat: "svg", ICO: true})
+		if err != nil || proc.Name() != "vector" {
+			t.Errorf("svg job detected %v (err %v), want vector", proc, err)
+		}
+
+		_, err = DetectAvailableProcessor("", Requirements{Upscale: true})
+		if err == nil || !strings.Contains(err.Error(), "cannot upscale") {
+			t.Errorf("upscale job error = %v, want capability explanation", err)
+		}
+
+		_, err = DetectAvailableProcessor("rasteronly", Requirements{InputFormat: "svg"})
+		if err == nil || !strings.Contains(err.Error(), "cannot read svg") {
+			t.Errorf("preferred processor error = %v, want capability explanation", err)
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package processor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Size is the pixel size of an output canvas
+type Size struct {
+	Width  int
+	Height int
+}
+
+// Square returns a size x size canvas
+func Square(size int) Size {
+	return Size{Width: size, Height: size}
+}
+
+// Squares returns a square canvas for every size
+func Squares(sizes ...int) []Size {
+	out := make([]Size, 0, len(sizes))
+	for _, size := range sizes {
+		out = append(out, Square(size))
+	}
+	return out
+}
+
+// ParseSize parses a size such as "32" or "310x150"
+func ParseSize(s string) (Size, error) {
+	s = strings.TrimSpace(s)
+	w, h, rect := strings.Cut(strings.ToLower(s), "x")
+	if !rect {
+		h = w
+	}
+
+	width, errW := strconv.Atoi(w)
+	height, errH := strconv.Atoi(h)
+	if errW != nil || errH != nil {
+		return Size{}, fmt.Errorf("invalid size: %s", s)
+	}
+	if width <= 0 || height <= 0 {
+		return Size{}, fmt.Errorf("size must be positive: %s", s)
+	}
+	return Size{Width: width, Height: height}, nil
+}
+
+// IsSquare reports whether the canvas is square
+func (s Size) IsSquare() bool {
+	return s.Width == s.Height
+}
+
+// Min returns the shorter side of the canvas
+func (s Size) Min() int {
+	return min(s.Width, s.Height)
+}
+
+// Max returns the longer side of the canvas
+func (s Size) Max() int {
+	return max(s.Width, s.Height)
+}
+
+// String formats the size as WxH, as used in file names and sizes
+// attributes
+func (s Size) String() string {
+	return fmt.Sprintf("%dx%d", s.Width, s.Height)
+}
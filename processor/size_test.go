@@ -0,0 +1,41 @@
+package processor
+
+import "testing"
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Size
+		wantErr bool
+	}{
+		{in: "32", want: Size{Width: 32, Height: 32}},
+		{in: " 310x150 ", want: Size{Width: 310, Height: 150}},
+		{in: "1200X630", want: Size{Width: 1200, Height: 630}},
+		{in: "0", wantErr: true},
+		{in: "32x0", wantErr: true},
+		{in: "-16", wantErr: true},
+		{in: "x32", wantErr: true},
+		{in: "32x32x32", wantErr: true},
+		{in: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseSize(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSize(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseSize(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSizeString(t *testing.T) {
+	if got := Square(16).String(); got != "16x16" {
+		t.Errorf("Square(16).String() = %q, want 16x16", got)
+	}
+	if got := (Size{Width: 310, Height: 150}).String(); got != "310x150" {
+		t.Errorf("String() = %q, want 310x150", got)
+	}
+}
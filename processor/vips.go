@@ -0,0 +1,120 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	Register("vips", func() Processor { return &VipsProcessor{} }, 200)
+}
+
+// VipsProcessor implements image processing using libvips
+type VipsProcessor struct{}
+
+func (p *VipsProcessor) Name() string {
+	return "vips"
+}
+
+func (p *VipsProcessor) IsAvailable() bool {
+	return commandExists("vips") && commandExists("vipsheader")
+}
+
+func (p *VipsProcessor) Capabilities() Capabilities {
+	inputs := []string{"png", "jpg", "jpeg", "gif", "webp", "tiff"}
+	if p.canReadSVG() {
+		inputs = append(inputs, "svg")
+	}
+
+	return Capabilities{
+		InputFormats:  inputs,
+		OutputFormats: []string{"png", "ico"},
+		// ICO files are written by the built-in encoder
+		ICO:     true,
+		Upscale: true,
+	}
+}
+
+// canReadSVG checks whether libvips was built with an SVG loader
+func (p *VipsProcessor) canReadSVG() bool {
+	output, err := exec.Command("vips", "-l", "foreign").Output()
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(output), "svgload")
+}
+
+func (p *VipsProcessor) Resize(ctx context.Context, inputPath, outputPath string, size Size, opts ResizeOptions) error {
+	srcW, srcH, err := p.dimensions(ctx, inputPath)
+	if err != nil {
+		return fmt.Errorf("vips resize failed: %w", err)
+	}
+	l := computeLayout(srcW, srcH, size, opts)
+
+	tmpDir, err := os.MkdirTemp("", "favicongen-vips-*")
+	if err != nil {
+		return fmt.Errorf("vips resize failed: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// Scale to exactly the layout size; the aspect ratio is already preserved
+	scaledPath := filepath.Join(tmpDir, "scaled.png")
+	output, err := runCommand(ctx, "vips",
+		"thumbnail",
+		inputPath,
+		scaledPath,
+		strconv.Itoa(l.width),
+		"--height", strconv.Itoa(l.height),
+		"--size", "force",
+	)
+	if err != nil {
+		return fmt.Errorf("vips resize failed: %w, output: %s", err, string(output))
+	}
+
+	// vips thumbnail cannot pad, fill or threshold, so place the result on
+	// the canvas in-process
+	scaled, err := decodeImage(scaledPath)
+	if err != nil {
+		return fmt.Errorf("vips resize failed: %w", err)
+	}
+	if err := encodePNG(outputPath, renderCanvas(scaled, size, l, opts)); err != nil {
+		return fmt.Errorf("vips resize failed: %w", err)
+	}
+
+	return nil
+}
+
+// dimensions reads the pixel size of an image with vipsheader
+func (p *VipsProcessor) dimensions(ctx context.Context, path string) (int, int, error) {
+	var dims [2]int
+	for i, field := range []string{"width", "height"} {
+		output, err := commandOutput(ctx, "vipsheader", "-f", field, path)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to read image %s: %w", field, err)
+		}
+
+		dims[i], err = strconv.Atoi(strings.TrimSpace(string(output)))
+		if err != nil || dims[i] <= 0 {
+			return 0, 0, fmt.Errorf("invalid image %s %q", field, strings.TrimSpace(string(output)))
+		}
+	}
+	return dims[0], dims[1], nil
+}
+
+func (p *VipsProcessor) ConvertToICO(ctx context.Context, inputPaths []string, outputPath string) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("vips ico conversion failed: %w", err)
+	}
+
+	// Vips doesn't support ICO creation directly, use the built-in encoder
+	if err := WriteICO(inputPaths, outputPath, ICOEncodingPNG); err != nil {
+		return fmt.Errorf("vips ico conversion failed: %w", err)
+	}
+
+	return nil
+}
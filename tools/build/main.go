@@ -55,7 +55,7 @@ func main() {
 	flag.Parse()
 
 	// Build ldflags with version information
-	ldflags = fmt.Sprintf("-s -w -X main.Version=%s -X main.BuildDate=%s -X main.CommitHash=%s",
+	ldflags = fmt.Sprintf("-s -w -X github.com/fathurrohman26/favicongen/cli.Version=%s -X github.com/fathurrohman26/favicongen/cli.BuildDate=%s -X github.com/fathurrohman26/favicongen/cli.CommitHash=%s",
 		Version, BuildDate, CommitHash)
 
 	checksumFile = filepath.Join(outputDir, "checksums.txt")